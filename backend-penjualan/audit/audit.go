@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"backend-penjualan/auth"
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Entity yang dicatat di audit log
+const (
+	EntityProduct     = "product"
+	EntityTransaction = "transaction"
+	EntityOrder       = "order"
+)
+
+// Field yang gak ikut dibandingkan (berubah tiap update, bukan perubahan dari user)
+var ignoredFields = map[string]bool{
+	"updated_at": true,
+}
+
+// Entry data satu perubahan. Before nil = create, After nil = delete.
+type Entry struct {
+	Action   string
+	Entity   string
+	EntityID uint
+	StoreID  *uint
+	Before   interface{}
+	After    interface{}
+}
+
+// Record simpan audit log pakai tx yang sama dengan perubahannya (ikut rollback kalau perubahan gagal).
+// Update tanpa field yang berubah gak dicatat.
+func Record(tx *gorm.DB, c *gin.Context, entry Entry) error {
+	changes, err := Diff(entry.Before, entry.After)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 && entry.Action == models.AuditActionUpdate {
+		return nil
+	}
+	log := models.AuditLog{
+		Action:    entry.Action,
+		Entity:    entry.Entity,
+		EntityID:  entry.EntityID,
+		StoreID:   entry.StoreID,
+		Changes:   changes,
+		RequestID: CurrentRequestID(c),
+		IP:        c.ClientIP(),
+	}
+	setActor(c, &log)
+	return tx.Create(&log).Error
+}
+
+// setActor isi actor dari user login atau API key yang dipakai request
+func setActor(c *gin.Context, log *models.AuditLog) {
+	if apiKey, ok := auth.CurrentAPIKey(c); ok {
+		log.ActorType = models.AuditActorAPIKey
+		log.ActorID = &apiKey.ID
+		log.ActorName = apiKey.Name
+		return
+	}
+	if claims, ok := auth.CurrentUser(c); ok {
+		userID := claims.UserID()
+		log.ActorType = models.AuditActorUser
+		log.ActorID = &userID
+		log.ActorName = claims.Username
+	}
+}
+
+// Diff bandingkan dua snapshot (struct model) lewat bentuk JSON-nya, return field yang berubah.
+// Relasi yang ikut ter-preload (object / array) di-skip, cukup foreign key-nya.
+func Diff(before, after interface{}) (map[string]models.AuditChange, error) {
+	beforeFields, err := snapshot(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := snapshot(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]models.AuditChange{}
+	for field, value := range beforeFields {
+		if other, ok := afterFields[field]; !ok || !reflect.DeepEqual(value, other) {
+			changes[field] = models.AuditChange{Before: value, After: afterFields[field]}
+		}
+	}
+	for field, value := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			changes[field] = models.AuditChange{After: value}
+		}
+	}
+	return changes, nil
+}
+
+// snapshot ubah model ke map field JSON -> nilai (tanpa relasi & field yang di-ignore)
+func snapshot(v interface{}) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if v == nil || reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil() {
+		return fields, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	for field, value := range raw {
+		if ignoredFields[field] {
+			continue
+		}
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			continue
+		}
+		fields[field] = value
+	}
+	return fields, nil
+}
@@ -0,0 +1,181 @@
+package audit
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend-penjualan/auth"
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestDiff(t *testing.T) {
+	before := models.Product{ID: 1, StoreID: 2, Nama: "Kopi", Harga: 15000, Stok: 10, UpdatedAt: time.Now()}
+	after := before
+	after.Stok = 8
+	after.UpdatedAt = before.UpdatedAt.Add(time.Minute)
+
+	tests := []struct {
+		name   string
+		before interface{}
+		after  interface{}
+		want   map[string]string // field -> "before->after"
+	}{
+		{"update only changed fields", before, after, map[string]string{"stok": "10->8"}},
+		{"no changes", before, before, map[string]string{}},
+		{"create", nil, &models.Transaction{ID: 3, Quantity: 2}, map[string]string{"id": "<nil>->3", "quantity": "<nil>->2"}},
+		{"delete", &models.Transaction{ID: 3, Quantity: 2}, (*models.Transaction)(nil), map[string]string{"id": "3-><nil>", "quantity": "2-><nil>"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := Diff(tt.before, tt.after)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			if _, ok := changes["updated_at"]; ok {
+				t.Error("updated_at should be ignored")
+			}
+			for field, want := range tt.want {
+				change, ok := changes[field]
+				if got := fmt.Sprintf("%v->%v", change.Before, change.After); !ok || got != want {
+					t.Errorf("changes[%s] = %s (present %v), want %s", field, got, ok, want)
+				}
+			}
+			if len(tt.want) == 0 && len(changes) != 0 {
+				t.Errorf("changes = %v, want none", changes)
+			}
+		})
+	}
+}
+
+func TestDiffSkipsRelations(t *testing.T) {
+	order := models.Order{ID: 1, Total: 30000, Items: []models.OrderItem{{ProductID: 7, Quantity: 2}}}
+	changes, err := Diff(nil, order)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if _, ok := changes["items"]; ok {
+		t.Error("preloaded items should not be diffed")
+	}
+	if _, ok := changes["total"]; !ok {
+		t.Error("total missing from changes")
+	}
+}
+
+// dryRunDB gorm DB tanpa koneksi; audit log yang mau di-insert ditangkap lewat callback
+func dryRunDB(t *testing.T) (*gorm.DB, *[]models.AuditLog) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	var logs []models.AuditLog
+	db.Callback().Create().After("gorm:create").Register("test:capture_audit", func(tx *gorm.DB) {
+		if log, ok := tx.Statement.Dest.(*models.AuditLog); ok {
+			logs = append(logs, *log)
+		}
+	})
+	return db, &logs
+}
+
+func TestRecord(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := auth.Config{Secret: []byte(strings.Repeat("s", 32)), Issuer: "test", AccessTTL: time.Minute}
+	token, _, err := cfg.IssueAccessToken(models.User{ID: 9, Username: "manager1", Role: models.RoleManager})
+	if err != nil {
+		t.Fatalf("IssueAccessToken() error = %v", err)
+	}
+	storeID := uint(2)
+	before := models.Product{ID: 1, StoreID: storeID, Nama: "Kopi", Stok: 10}
+	after := before
+	after.Stok = 8
+
+	tests := []struct {
+		name    string
+		entry   Entry
+		wantLog bool
+	}{
+		{"create", Entry{Action: models.AuditActionCreate, Entity: EntityProduct, EntityID: 1, StoreID: &storeID, After: after}, true},
+		{"update", Entry{Action: models.AuditActionUpdate, Entity: EntityProduct, EntityID: 1, StoreID: &storeID, Before: before, After: after}, true},
+		{"update without changes", Entry{Action: models.AuditActionUpdate, Entity: EntityProduct, EntityID: 1, Before: before, After: before}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, logs := dryRunDB(t)
+			r := gin.New()
+			r.POST("/products", RequestID(), auth.Middleware(cfg, nil), func(c *gin.Context) {
+				if err := Record(db, c, tt.entry); err != nil {
+					t.Errorf("Record() error = %v", err)
+				}
+			})
+			req := httptest.NewRequest(http.MethodPost, "/products", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set(RequestIDHeader, "req-123")
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			if !tt.wantLog {
+				if len(*logs) != 0 {
+					t.Errorf("logs = %+v, want none", *logs)
+				}
+				return
+			}
+			if len(*logs) != 1 {
+				t.Fatalf("logs = %d, want 1", len(*logs))
+			}
+			log := (*logs)[0]
+			if log.Action != tt.entry.Action || log.Entity != EntityProduct || log.EntityID != 1 || log.StoreID == nil || *log.StoreID != storeID {
+				t.Errorf("log = %+v, want %s product 1 in store %d", log, tt.entry.Action, storeID)
+			}
+			if log.ActorType != models.AuditActorUser || log.ActorID == nil || *log.ActorID != 9 || log.ActorName != "manager1" {
+				t.Errorf("actor = %s %v %s, want user 9 manager1", log.ActorType, log.ActorID, log.ActorName)
+			}
+			if log.RequestID != "req-123" {
+				t.Errorf("request ID = %q, want req-123", log.RequestID)
+			}
+			if change, ok := log.Changes["stok"]; !ok || fmt.Sprint(change.After) != "8" {
+				t.Errorf("changes = %v, want stok after 8", log.Changes)
+			}
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{"client ID kept", "abc-123", true},
+		{"generated when missing", "", false},
+		{"unsafe ID replaced", "abc 123\n", false},
+		{"too long ID replaced", strings.Repeat("a", 101), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var current string
+			r := gin.New()
+			r.GET("/ping", RequestID(), func(c *gin.Context) { current = CurrentRequestID(c) })
+			req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if current == "" || w.Header().Get(RequestIDHeader) != current {
+				t.Fatalf("request ID = %q, header = %q, want the same non-empty ID", current, w.Header().Get(RequestIDHeader))
+			}
+			if (current == tt.header) != tt.keep {
+				t.Errorf("request ID = %q, keep client ID %v", current, tt.keep)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader header ID request (diterima dari client/proxy atau dibuat baru, selalu dikirim balik di response)
+const RequestIDHeader = "X-Request-ID"
+
+const requestIDContextKey = "audit.request_id"
+
+// Request ID dari luar cuma diterima kalau aman ditulis ke log & DB
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,100}$`)
+
+// RequestID middleware: pakai X-Request-ID dari client kalau valid, kalau gak buat ID random
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+		c.Set(requestIDContextKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// CurrentRequestID ID request yang sedang jalan ("" kalau route gak lewat RequestID)
+func CurrentRequestID(c *gin.Context) string {
+	return c.GetString(requestIDContextKey)
+}
+
+func newRequestID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(buf)
+}
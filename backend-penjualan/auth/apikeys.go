@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"backend-penjualan/models"
+
+	"gorm.io/gorm"
+)
+
+// APIKeyPrefix awalan semua API key, biar middleware bisa bedakan dari JWT
+const APIKeyPrefix = "pnj_"
+
+// apiKeyUsageInterval last_used_at cuma di-update kalau sudah lewat interval ini (hemat write per request)
+const apiKeyUsageInterval = time.Minute
+
+var ErrInvalidAPIKey = errors.New("invalid, expired or revoked API key")
+
+// Scopes semua scope API key yang valid
+var Scopes = []string{models.ScopeRead, models.ScopeTransactionsWrite, models.ScopeForecast}
+
+// NewAPIKey buat key random: key (dikirim ke client sekali), prefix (buat identifikasi) & hash (disimpan di DB)
+func NewAPIKey() (key, prefix, hash string, err error) {
+	buf := make([]byte, 30)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", "", err
+	}
+	key = APIKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return key, key[:len(APIKeyPrefix)+8], HashToken(key), nil
+}
+
+// authenticateAPIKey cari key aktif dari hash-nya & catat pemakaian terakhir
+func authenticateAPIKey(db *gorm.DB, key, ip string) (*models.APIKey, error) {
+	var apiKey models.APIKey
+	err := db.Where("key_hash = ? AND revoked_at IS NULL", HashToken(key)).First(&apiKey).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if apiKey.ExpiresAt != nil && now.After(*apiKey.ExpiresAt) {
+		return nil, ErrInvalidAPIKey
+	}
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyUsageInterval {
+		// Gagal catat pemakaian gak menggagalkan request
+		db.Model(&apiKey).UpdateColumns(map[string]any{"last_used_at": now, "last_used_ip": ip})
+		apiKey.LastUsedAt = &now
+		apiKey.LastUsedIP = ip
+	}
+	return &apiKey, nil
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	key, prefix, hash, err := NewAPIKey()
+	if err != nil {
+		t.Fatalf("NewAPIKey() error = %v", err)
+	}
+	if !strings.HasPrefix(key, APIKeyPrefix) || !strings.HasPrefix(key, prefix) || len(prefix) != len(APIKeyPrefix)+8 {
+		t.Errorf("key = %q prefix = %q, want %s key starting with an 8 character prefix", key, prefix, APIKeyPrefix)
+	}
+	if hash != HashToken(key) {
+		t.Errorf("hash = %q, want HashToken(key)", hash)
+	}
+}
+
+func TestAuthorizeAPIKeyScopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name       string
+		scopes     []string
+		scope      string
+		roles      []string
+		wantStatus int
+		wantError  string
+	}{
+		{"has scope", []string{models.ScopeRead}, models.ScopeRead, nil, http.StatusNoContent, ""},
+		{"one of several scopes", []string{models.ScopeRead, models.ScopeForecast}, models.ScopeForecast, nil, http.StatusNoContent, ""},
+		{"roles do not apply to API keys", []string{models.ScopeTransactionsWrite}, models.ScopeTransactionsWrite, []string{models.RoleAdmin}, http.StatusNoContent, ""},
+		{"missing scope", []string{models.ScopeRead}, models.ScopeTransactionsWrite, nil, http.StatusForbidden, "requires scope transactions:write"},
+		{"no scopes", nil, models.ScopeRead, nil, http.StatusForbidden, "requires scope read"},
+		{"user-only endpoint", []string{models.ScopeRead, models.ScopeTransactionsWrite, models.ScopeForecast}, "", nil, http.StatusForbidden, "API keys cannot access this endpoint"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/ping", func(c *gin.Context) {
+				c.Set(apiKeyContextKey, &models.APIKey{ID: 1, Name: "pos", Scopes: tt.scopes})
+			}, Authorize(tt.scope, tt.roles...), func(c *gin.Context) {
+				c.Status(http.StatusNoContent)
+			})
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantError) {
+				t.Errorf("body = %s, want %q", w.Body, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestScopesAreKnown(t *testing.T) {
+	key := models.APIKey{Scopes: Scopes}
+	for _, scope := range []string{models.ScopeRead, models.ScopeTransactionsWrite, models.ScopeForecast} {
+		if !key.HasScope(scope) {
+			t.Errorf("Scopes missing %q", scope)
+		}
+	}
+	if key.HasScope("admin") {
+		t.Error("HasScope(admin) = true for an unknown scope")
+	}
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"log"
+	"os"
+	"strings"
+
+	"backend-penjualan/models"
+
+	"gorm.io/gorm"
+)
+
+// BootstrapAdmin buat user admin pertama dari ADMIN_USERNAME (default "admin") & ADMIN_PASSWORD
+// kalau tabel users masih kosong. Kalau sudah ada user, env diabaikan.
+func BootstrapAdmin(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&models.User{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	password := os.Getenv("ADMIN_PASSWORD")
+	if password == "" {
+		log.Println("WARNING: no users yet and ADMIN_PASSWORD not set, nobody can log in")
+		return nil
+	}
+	username := strings.ToLower(strings.TrimSpace(os.Getenv("ADMIN_USERNAME")))
+	if username == "" {
+		username = "admin"
+	}
+	hash, err := HashPassword(password)
+	if err != nil {
+		return err
+	}
+	admin := models.User{Username: username, Nama: "Administrator", PasswordHash: hash, Role: models.RoleAdmin, Active: true}
+	if err := db.Create(&admin).Error; err != nil {
+		return err
+	}
+	log.Printf("Admin user %q created", username)
+	return nil
+}
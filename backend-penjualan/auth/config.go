@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"crypto/rand"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// Config setting JWT (dari env)
+type Config struct {
+	Secret     []byte        // JWT_SECRET, buat sign access token (HS256)
+	Issuer     string        // Claim iss
+	AccessTTL  time.Duration // JWT_ACCESS_TTL, umur access token (default 15m)
+	RefreshTTL time.Duration // JWT_REFRESH_TTL, umur refresh token / sesi (default 168h)
+}
+
+// ConfigFromEnv baca JWT_SECRET, JWT_ACCESS_TTL, JWT_REFRESH_TTL.
+// JWT_SECRET wajib (min. 32 karakter), kecuali APP_ENV=development: secret random dibuat tiap start
+// (semua sesi hilang kalau server restart).
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		Secret:     []byte(os.Getenv("JWT_SECRET")),
+		Issuer:     "backend-penjualan",
+		AccessTTL:  15 * time.Minute,
+		RefreshTTL: 7 * 24 * time.Hour,
+	}
+	if d, err := time.ParseDuration(os.Getenv("JWT_ACCESS_TTL")); err == nil && d > 0 {
+		cfg.AccessTTL = d
+	}
+	if d, err := time.ParseDuration(os.Getenv("JWT_REFRESH_TTL")); err == nil && d > 0 {
+		cfg.RefreshTTL = d
+	}
+
+	if len(cfg.Secret) == 0 && os.Getenv("APP_ENV") == "development" {
+		cfg.Secret = make([]byte, 32)
+		if _, err := rand.Read(cfg.Secret); err != nil {
+			return cfg, err
+		}
+		log.Println("WARNING: JWT_SECRET not set, using random secret (sessions reset on restart)")
+	}
+	if len(cfg.Secret) < 32 {
+		return cfg, fmt.Errorf("JWT_SECRET must be set (at least 32 characters)")
+	}
+	return cfg, nil
+}
@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Key gin.Context buat user / API key yang sedang request
+const (
+	contextKey       = "auth.claims"
+	apiKeyContextKey = "auth.api_key"
+)
+
+// Middleware wajibkan header Authorization berisi access token user ("Bearer <jwt>") atau API key
+// ("Bearer pnj_..." / "ApiKey pnj_..."). Claims user disimpan di context (lihat CurrentUser),
+// API key lewat CurrentAPIKey. Hak akses per route dicek Authorize.
+func Middleware(cfg Config, db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		scheme, token, _ := strings.Cut(header, " ")
+		token = strings.TrimSpace(token)
+		if token == "" || (!strings.EqualFold(scheme, "Bearer") && !strings.EqualFold(scheme, "ApiKey")) {
+			abortUnauthorized(c, "Missing bearer token or API key")
+			return
+		}
+
+		if strings.HasPrefix(token, APIKeyPrefix) {
+			apiKey, err := authenticateAPIKey(db.WithContext(c.Request.Context()), token, c.ClientIP())
+			if err != nil {
+				if errors.Is(err, ErrInvalidAPIKey) {
+					abortUnauthorized(c, "Invalid, expired or revoked API key")
+				} else {
+					c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				}
+				return
+			}
+			c.Set(apiKeyContextKey, apiKey)
+			c.Next()
+			return
+		}
+
+		if !strings.EqualFold(scheme, "Bearer") {
+			abortUnauthorized(c, "Invalid, expired or revoked API key")
+			return
+		}
+		claims, err := cfg.ParseAccessToken(token)
+		if err != nil {
+			abortUnauthorized(c, "Invalid or expired token")
+			return
+		}
+		c.Set(contextKey, claims)
+		c.Next()
+	}
+}
+
+func abortUnauthorized(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", `Bearer realm="api"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": message})
+}
+
+// Authorize cek hak akses route (dipasang setelah Middleware):
+//   - user: role harus salah satu dari roles (kosong = semua role boleh)
+//   - API key: harus punya scope (scope kosong = API key gak boleh akses route ini)
+func Authorize(scope string, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if apiKey, ok := CurrentAPIKey(c); ok {
+			if scope == "" || !apiKey.HasScope(scope) {
+				message := "Forbidden: API keys cannot access this endpoint"
+				if scope != "" {
+					message = "Forbidden: API key requires scope " + scope
+				}
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": message})
+				return
+			}
+			c.Next()
+			return
+		}
+
+		claims, ok := CurrentUser(c)
+		if !ok {
+			abortUnauthorized(c, "Missing bearer token or API key")
+			return
+		}
+		if len(roles) > 0 && !slices.Contains(roles, claims.Role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "Forbidden: requires role " + strings.Join(roles, " or "),
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// CurrentUser claims user yang login (false kalau request pakai API key / route gak lewat Middleware)
+func CurrentUser(c *gin.Context) (*Claims, bool) {
+	value, ok := c.Get(contextKey)
+	if !ok {
+		return nil, false
+	}
+	claims, ok := value.(*Claims)
+	return claims, ok
+}
+
+// CurrentAPIKey API key yang dipakai request (false kalau request dari user login)
+func CurrentAPIKey(c *gin.Context) (*models.APIKey, bool) {
+	value, ok := c.Get(apiKeyContextKey)
+	if !ok {
+		return nil, false
+	}
+	apiKey, ok := value.(*models.APIKey)
+	return apiKey, ok
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newTestRouter GET /ping lewat Middleware + Authorize(scope, roles...)
+func newTestRouter(cfg Config, scope string, roles ...string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/ping", Middleware(cfg, nil), Authorize(scope, roles...), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	return r
+}
+
+func TestMiddlewareAndRoles(t *testing.T) {
+	cfg := testConfig()
+	tokenFor := func(role string) string {
+		token, _, err := cfg.IssueAccessToken(models.User{ID: 1, Username: role, Role: role})
+		if err != nil {
+			t.Fatalf("IssueAccessToken() error = %v", err)
+		}
+		return token
+	}
+	managers := []string{models.RoleAdmin, models.RoleManager}
+	tests := []struct {
+		name       string
+		header     string
+		roles      []string
+		wantStatus int
+	}{
+		{"missing header", "", nil, http.StatusUnauthorized},
+		{"unknown scheme", "Basic " + tokenFor(models.RoleAdmin), nil, http.StatusUnauthorized},
+		{"jwt with ApiKey scheme", "ApiKey " + tokenFor(models.RoleAdmin), nil, http.StatusUnauthorized},
+		{"invalid token", "Bearer abc.def.ghi", nil, http.StatusUnauthorized},
+		{"any role", "Bearer " + tokenFor(models.RoleCashier), nil, http.StatusNoContent},
+		{"scheme is case insensitive", "bearer " + tokenFor(models.RoleCashier), nil, http.StatusNoContent},
+		{"manager allowed", "Bearer " + tokenFor(models.RoleManager), managers, http.StatusNoContent},
+		{"admin allowed", "Bearer " + tokenFor(models.RoleAdmin), managers, http.StatusNoContent},
+		{"cashier forbidden", "Bearer " + tokenFor(models.RoleCashier), managers, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			newTestRouter(cfg, models.ScopeRead, tt.roles...).ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if w.Code == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+				t.Error("401 without WWW-Authenticate header")
+			}
+		})
+	}
+}
+
+func TestAuthorizeWithoutMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/ping", nil)
+
+	Authorize("")(c)
+
+	if w.Code != http.StatusUnauthorized || !c.IsAborted() {
+		t.Errorf("status = %d aborted = %v, want 401 and aborted", w.Code, c.IsAborted())
+	}
+}
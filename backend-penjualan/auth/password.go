@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MinPasswordLength panjang minimal password user
+const MinPasswordLength = 8
+
+// HashPassword hash bcrypt (cost default) buat disimpan di users.password_hash
+func HashPassword(password string) (string, error) {
+	if len(password) < MinPasswordLength {
+		return "", fmt.Errorf("password must be at least %d characters", MinPasswordLength)
+	}
+	// bcrypt cuma pakai 72 byte pertama, lebih dari itu ditolak biar gak ada password yang diam-diam dipotong
+	if len(password) > 72 {
+		return "", fmt.Errorf("password must be at most 72 bytes")
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword cocokkan password dengan hash bcrypt
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
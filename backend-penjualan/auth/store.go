@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// StoreHeader header buat pilih toko (wajib buat input data oleh user kantor pusat)
+const StoreHeader = "X-Store-ID"
+
+const storeContextKey = "auth.store_id"
+
+// homeStore toko user / API key yang sedang request (nil = kantor pusat)
+func homeStore(c *gin.Context) *uint {
+	if apiKey, ok := CurrentAPIKey(c); ok {
+		return apiKey.StoreID
+	}
+	if claims, ok := CurrentUser(c); ok {
+		return claims.StoreID
+	}
+	return nil
+}
+
+// StoreContext tentukan toko aktif request (dipasang setelah Middleware):
+//   - user/API key cabang: selalu tokonya sendiri; X-Store-ID toko lain ditolak 403
+//   - kantor pusat: toko dari X-Store-ID, kalau kosong semua toko (laporan konsolidasi)
+//
+// Controller baca hasilnya lewat CurrentStoreID.
+func StoreContext(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		home := homeStore(c)
+		header := c.GetHeader(StoreHeader)
+		if header == "" {
+			if home != nil {
+				c.Set(storeContextKey, *home)
+			}
+			c.Next()
+			return
+		}
+
+		id, err := strconv.ParseUint(header, 10, 64)
+		if err != nil || id == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid " + StoreHeader + " (must be number)"})
+			return
+		}
+		storeID := uint(id)
+		if home != nil {
+			if *home != storeID {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "No access to store " + header})
+				return
+			}
+		} else {
+			var store models.Store
+			if err := db.WithContext(c.Request.Context()).Select("id").First(&store, storeID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Store not found"})
+				} else {
+					c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				}
+				return
+			}
+		}
+		c.Set(storeContextKey, storeID)
+		c.Next()
+	}
+}
+
+// CurrentStoreID toko aktif request (nil = semua toko, user kantor pusat tanpa X-Store-ID)
+func CurrentStoreID(c *gin.Context) *uint {
+	value, ok := c.Get(storeContextKey)
+	if !ok {
+		return nil
+	}
+	storeID, ok := value.(uint)
+	if !ok {
+		return nil
+	}
+	return &storeID
+}
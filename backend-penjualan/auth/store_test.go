@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestStoreContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := testConfig()
+	branch := uint(2)
+	tests := []struct {
+		name       string
+		storeID    *uint
+		apiKey     bool
+		header     string
+		wantStatus int
+		wantStore  string
+	}{
+		{"branch user defaults to own store", &branch, false, "", http.StatusOK, "2"},
+		{"branch user may name own store", &branch, false, "2", http.StatusOK, "2"},
+		{"branch user cannot pick another store", &branch, false, "3", http.StatusForbidden, ""},
+		{"branch API key cannot pick another store", &branch, true, "3", http.StatusForbidden, ""},
+		{"head office without header sees all stores", nil, false, "", http.StatusOK, "<nil>"},
+		{"invalid header", nil, false, "abc", http.StatusBadRequest, ""},
+		{"zero store", &branch, false, "0", http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			authenticate := Middleware(cfg, nil)
+			if tt.apiKey {
+				authenticate = func(c *gin.Context) {
+					c.Set(apiKeyContextKey, &models.APIKey{ID: 1, StoreID: tt.storeID})
+				}
+			}
+			r.GET("/ping", authenticate, StoreContext(nil), func(c *gin.Context) {
+				store := "<nil>"
+				if id := CurrentStoreID(c); id != nil {
+					store = fmt.Sprint(*id)
+				}
+				c.String(http.StatusOK, store)
+			})
+			token, _, err := cfg.IssueAccessToken(models.User{ID: 1, Username: "u", Role: models.RoleCashier, StoreID: tt.storeID})
+			if err != nil {
+				t.Fatalf("IssueAccessToken() error = %v", err)
+			}
+			req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			if tt.header != "" {
+				req.Header.Set(StoreHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantStore != "" && w.Body.String() != tt.wantStore {
+				t.Errorf("CurrentStoreID() = %s, want %s", w.Body, tt.wantStore)
+			}
+		})
+	}
+}
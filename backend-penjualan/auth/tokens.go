@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+
+	"backend-penjualan/models"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// Claims isi access token JWT (sub = user ID)
+type Claims struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	StoreID  *uint  `json:"store_id,omitempty"` // Kosong = user kantor pusat
+	jwt.RegisteredClaims
+}
+
+// UserID user ID dari claim sub
+func (c *Claims) UserID() uint {
+	id, _ := strconv.ParseUint(c.Subject, 10, 64)
+	return uint(id)
+}
+
+// IssueAccessToken buat access token JWT (HS256) buat user
+func (cfg Config) IssueAccessToken(user models.User) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(cfg.AccessTTL)
+	claims := Claims{
+		Username: user.Username,
+		Role:     user.Role,
+		StoreID:  user.StoreID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatUint(uint64(user.ID), 10),
+			Issuer:    cfg.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(cfg.Secret)
+	return token, expiresAt, err
+}
+
+// ParseAccessToken validasi signature, algoritma, issuer & expiry access token
+func (cfg Config) ParseAccessToken(token string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+		return cfg.Secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithIssuer(cfg.Issuer), jwt.WithExpirationRequired())
+	if err != nil || claims.UserID() == 0 {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// NewRefreshToken token random (dikirim ke client) + hash-nya (disimpan di DB)
+func NewRefreshToken() (token, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashToken(token), nil
+}
+
+// HashToken SHA-256 hex token (refresh token disimpan dalam bentuk hash)
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
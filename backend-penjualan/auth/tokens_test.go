@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"backend-penjualan/models"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func testConfig() Config {
+	return Config{
+		Secret:     []byte(strings.Repeat("s", 32)),
+		Issuer:     "backend-penjualan",
+		AccessTTL:  time.Minute,
+		RefreshTTL: time.Hour,
+	}
+}
+
+func TestAccessTokenRoundTrip(t *testing.T) {
+	cfg := testConfig()
+	storeID := uint(2)
+	token, expiresAt, err := cfg.IssueAccessToken(models.User{ID: 5, Username: "kasir1", Role: models.RoleCashier, StoreID: &storeID})
+	if err != nil {
+		t.Fatalf("IssueAccessToken() error = %v", err)
+	}
+	if d := time.Until(expiresAt); d <= 0 || d > cfg.AccessTTL {
+		t.Errorf("expiresAt in %v, want within AccessTTL %v", d, cfg.AccessTTL)
+	}
+
+	claims, err := cfg.ParseAccessToken(token)
+	if err != nil {
+		t.Fatalf("ParseAccessToken() error = %v", err)
+	}
+	if claims.UserID() != 5 || claims.Username != "kasir1" || claims.Role != models.RoleCashier {
+		t.Errorf("claims = %+v, want user 5 kasir1 cashier", claims)
+	}
+	if claims.StoreID == nil || *claims.StoreID != storeID {
+		t.Errorf("claims.StoreID = %v, want %d", claims.StoreID, storeID)
+	}
+}
+
+func TestParseAccessTokenRejects(t *testing.T) {
+	cfg := testConfig()
+	sign := func(method jwt.SigningMethod, key any, mutate func(*Claims)) string {
+		claims := Claims{
+			Username: "admin",
+			Role:     models.RoleAdmin,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   "1",
+				Issuer:    cfg.Issuer,
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			},
+		}
+		if mutate != nil {
+			mutate(&claims)
+		}
+		token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+		return token
+	}
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"garbage", "not-a-jwt"},
+		{"wrong secret", sign(jwt.SigningMethodHS256, []byte(strings.Repeat("x", 32)), nil)},
+		{"other algorithm", sign(jwt.SigningMethodHS512, cfg.Secret, nil)},
+		{"alg none", sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, nil)},
+		{"expired", sign(jwt.SigningMethodHS256, cfg.Secret, func(c *Claims) {
+			c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+		})},
+		{"without expiry", sign(jwt.SigningMethodHS256, cfg.Secret, func(c *Claims) { c.ExpiresAt = nil })},
+		{"other issuer", sign(jwt.SigningMethodHS256, cfg.Secret, func(c *Claims) { c.Issuer = "other" })},
+		{"without user", sign(jwt.SigningMethodHS256, cfg.Secret, func(c *Claims) { c.Subject = "" })},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := cfg.ParseAccessToken(tt.token); !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("ParseAccessToken() error = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+}
+
+func TestRefreshTokenHash(t *testing.T) {
+	token, hash, err := NewRefreshToken()
+	if err != nil {
+		t.Fatalf("NewRefreshToken() error = %v", err)
+	}
+	if hash != HashToken(token) || hash == token {
+		t.Errorf("hash = %q, want HashToken(token) and different from the token", hash)
+	}
+	other, _, _ := NewRefreshToken()
+	if other == token {
+		t.Error("NewRefreshToken() returned the same token twice")
+	}
+}
+
+func TestHashPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		wantErr  bool
+	}{
+		{"valid", "rahasia123", false},
+		{"too short", "pendek", true},
+		{"longer than bcrypt input", strings.Repeat("a", 73), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := HashPassword(tt.password)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("HashPassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !CheckPassword(hash, tt.password) || CheckPassword(hash, tt.password+"x") {
+				t.Error("CheckPassword() does not match only the original password")
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Package cache TTL cache in-process (dengan batas jumlah entry) untuk hasil forecast,
+// opsional dipersist ke Postgres biar tetap ada setelah server restart / dipakai bareng antar instance.
+package cache
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Store penyimpanan kedua di belakang cache memory (mis. Postgres)
+type Store interface {
+	Get(key string) (value []byte, expiresAt time.Time, found bool, err error)
+	Set(key string, value []byte, expiresAt time.Time) error
+}
+
+type entry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// Cache key -> value (bytes, biasanya JSON) dengan TTL
+type Cache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	items      map[string]entry
+	store      Store // Optional
+}
+
+// New buat cache; store boleh nil (memory saja)
+func New(ttl time.Duration, maxEntries int, store Store) *Cache {
+	return &Cache{ttl: ttl, maxEntries: maxEntries, items: make(map[string]entry), store: store}
+}
+
+// Get cek memory dulu, lalu store. Entry yang expired dianggap gak ada.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	now := time.Now()
+	c.mu.Lock()
+	e, ok := c.items[key]
+	if ok && now.After(e.expiresAt) {
+		delete(c.items, key)
+		ok = false
+	}
+	c.mu.Unlock()
+	if ok {
+		return e.value, true
+	}
+	if c.store == nil {
+		return nil, false
+	}
+
+	value, expiresAt, found, err := c.store.Get(key)
+	if err != nil {
+		log.Printf("Warning: cache store get failed: %v", err)
+		return nil, false
+	}
+	if !found || now.After(expiresAt) {
+		return nil, false
+	}
+	c.setMemory(key, entry{value: value, expiresAt: expiresAt})
+	return value, true
+}
+
+// Set simpan value dengan TTL cache (gagal simpan ke store cuma di-log)
+func (c *Cache) Set(key string, value []byte) {
+	e := entry{value: value, expiresAt: time.Now().Add(c.ttl)}
+	c.setMemory(key, e)
+	if c.store != nil {
+		if err := c.store.Set(key, value, e.expiresAt); err != nil {
+			log.Printf("Warning: cache store set failed: %v", err)
+		}
+	}
+}
+
+func (c *Cache) setMemory(key string, e entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.items[key]; !exists && c.maxEntries > 0 && len(c.items) >= c.maxEntries {
+		c.evict()
+	}
+	c.items[key] = e
+}
+
+// evict buang entry expired; kalau masih penuh buang yang paling cepat expired (= paling lama disimpan)
+func (c *Cache) evict() {
+	now := time.Now()
+	var oldestKey string
+	var oldest time.Time
+	for key, e := range c.items {
+		if now.After(e.expiresAt) {
+			delete(c.items, key)
+			continue
+		}
+		if oldestKey == "" || e.expiresAt.Before(oldest) {
+			oldestKey, oldest = key, e.expiresAt
+		}
+	}
+	if len(c.items) >= c.maxEntries && oldestKey != "" {
+		delete(c.items, oldestKey)
+	}
+}
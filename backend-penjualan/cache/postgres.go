@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"errors"
+	"time"
+
+	"backend-penjualan/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostgresStore Store di tabel forecast_cache
+type PostgresStore struct {
+	DB *gorm.DB
+}
+
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{DB: db}
+}
+
+func (s *PostgresStore) Get(key string) ([]byte, time.Time, bool, error) {
+	var e models.ForecastCacheEntry
+	err := s.DB.Where("key = ? AND expires_at > ?", key, time.Now()).First(&e).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, time.Time{}, false, nil
+	}
+	if err != nil {
+		return nil, time.Time{}, false, err
+	}
+	return e.Value, e.ExpiresAt, true, nil
+}
+
+func (s *PostgresStore) Set(key string, value []byte, expiresAt time.Time) error {
+	e := models.ForecastCacheEntry{Key: key, Value: value, ExpiresAt: expiresAt}
+	return s.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "expires_at", "created_at"}),
+	}).Create(&e).Error
+}
+
+// Purge hapus entry yang sudah expired
+func (s *PostgresStore) Purge() (int64, error) {
+	result := s.DB.Where("expires_at <= ?", time.Now()).Delete(&models.ForecastCacheEntry{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,184 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend-penjualan/auth"
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// APIKeyController godoc
+// @Description API key controller handles keys for machine-to-machine integrations (admin only)
+type APIKeyController struct {
+	DB *gorm.DB
+}
+
+func NewAPIKeyController(db *gorm.DB) *APIKeyController {
+	return &APIKeyController{DB: db}
+}
+
+// APIKeyInput body buat create API key
+type APIKeyInput struct {
+	Name          string   `json:"name" binding:"required"`   // Nama integrasi, mis. "ERP" atau "ML service"
+	Scopes        []string `json:"scopes" binding:"required"` // read / transactions:write / forecast
+	ExpiresInDays *int     `json:"expires_in_days"`           // Kosong = gak pernah expired
+	StoreID       uint     `json:"store_id"`                  // Toko yang boleh diakses; 0 = semua toko (pilih lewat X-Store-ID), admin cabang: tokonya sendiri
+}
+
+// APIKeyCreatedResponse godoc
+// @Description Newly created API key. The key is only shown once; send it as "Authorization: Bearer <key>".
+type APIKeyCreatedResponse struct {
+	models.APIKey
+	Key string `json:"key"`
+}
+
+// GetAll godoc
+// @Summary Get all API keys
+// @Description List API keys (without the secret key). Branch admins only see keys of their own store. Revoked keys are hidden unless include_revoked=true.
+// @Tags api-keys
+// @Produce json
+// @Param include_revoked query bool false "Include revoked keys"
+// @Success 200 {array} models.APIKey "List of API keys"
+// @Failure 400 {object} map[string]string "Invalid filter"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin role)"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api-keys [get]
+func (ctrl *APIKeyController) GetAll(c *gin.Context) {
+	query := ctrl.DB.Model(&models.APIKey{}).Scopes(homeStoreScope(c))
+	if includeStr := c.Query("include_revoked"); includeStr != "" {
+		include, err := strconv.ParseBool(includeStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid include_revoked (must be true or false)"})
+			return
+		}
+		if !include {
+			query = query.Where("revoked_at IS NULL")
+		}
+	} else {
+		query = query.Where("revoked_at IS NULL")
+	}
+	var keys []models.APIKey
+	if err := query.Order("id").Find(&keys).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, keys)
+}
+
+// Create godoc
+// @Summary Create an API key
+// @Description Create a scoped API key for an integration. Scopes: read (all GET endpoints except users & API keys), transactions:write (create transactions & orders), forecast (run forecasts). Set store_id to limit the key to one branch; keys created by branch admins are always limited to their own store. The key is returned only in this response.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param input body APIKeyInput true "Name, scopes & optional expiry"
+// @Success 201 {object} controllers.APIKeyCreatedResponse "Created API key (key shown once)"
+// @Failure 400 {object} map[string]string "Validation error"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin role; branch admins: other store)"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api-keys [post]
+func (ctrl *APIKeyController) Create(c *gin.Context) {
+	var input APIKeyInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len(name) > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Name required (max 100 characters)"})
+		return
+	}
+	var scopes []string
+	for _, scope := range input.Scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !slices.Contains(auth.Scopes, scope) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scope " + strconv.Quote(scope) + " (must be one of " + strings.Join(auth.Scopes, ", ") + ")"})
+			return
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one scope required"})
+		return
+	}
+
+	key, prefix, hash, err := auth.NewAPIKey()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	apiKey := models.APIKey{Name: name, Prefix: prefix, KeyHash: hash, Scopes: scopes}
+	if input.ExpiresInDays != nil {
+		if *input.ExpiresInDays < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "expires_in_days must be at least 1"})
+			return
+		}
+		expiresAt := time.Now().AddDate(0, 0, *input.ExpiresInDays)
+		apiKey.ExpiresAt = &expiresAt
+	}
+	// Admin cabang: key selalu untuk tokonya sendiri (0 = tokonya, bukan semua toko)
+	if homeStore := adminHomeStore(c); homeStore != nil && input.StoreID == 0 {
+		input.StoreID = *homeStore
+	}
+	storeID, err := resolveHomeStore(ctrl.DB, input.StoreID)
+	if errors.Is(err, errStoreNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Store not found"})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !checkAdminGrant(c, storeID, false) {
+		return
+	}
+	apiKey.StoreID = storeID
+	if claims, ok := auth.CurrentUser(c); ok {
+		userID := claims.UserID()
+		apiKey.CreatedByID = &userID
+	}
+	if err := ctrl.DB.Create(&apiKey).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, APIKeyCreatedResponse{APIKey: apiKey, Key: key})
+}
+
+// Revoke godoc
+// @Summary Revoke an API key
+// @Description Revoke an API key by ID; requests using it are rejected immediately
+// @Tags api-keys
+// @Produce json
+// @Param id path int true "API key ID"
+// @Success 200 {object} map[string]string "Success message"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin role)"
+// @Failure 404 {object} map[string]string "API key not found or already revoked"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api-keys/{id} [delete]
+func (ctrl *APIKeyController) Revoke(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	result := ctrl.DB.Model(&models.APIKey{}).Scopes(homeStoreScope(c)).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", time.Now())
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "API key not found or already revoked"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "API key revoked"})
+}
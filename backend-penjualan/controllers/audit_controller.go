@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AuditController godoc
+// @Description Audit controller exposes the change history of products and transactions
+type AuditController struct {
+	DB *gorm.DB
+}
+
+func NewAuditController(db *gorm.DB) *AuditController {
+	return &AuditController{DB: db}
+}
+
+// auditSortFields whitelist field yang boleh dipakai di ?sort=
+var auditSortFields = map[string]string{
+	"id":         "id",
+	"entity":     "entity",
+	"action":     "action",
+	"created_at": "created_at",
+}
+
+// GetAll godoc
+// @Summary List audit log
+// @Description Retrieve who created, updated or deleted products and transactions, newest first, with the changed fields (before/after). Scoped to the active store like other lists. Total rows returned in X-Total-Count header.
+// @Tags audit
+// @Produce json
+// @Param entity query string false "Filter by entity" Enums(product, transaction, order)
+// @Param entity_id query int false "Filter by entity ID (use with entity)"
+// @Param action query string false "Filter by action" Enums(create, update, delete)
+// @Param actor_type query string false "Filter by actor type" Enums(user, api_key)
+// @Param actor_id query int false "Filter by user / API key ID (use with actor_type)"
+// @Param request_id query string false "Filter by X-Request-ID"
+// @Param start_date query string false "Start date, inclusive (YYYY-MM-DD)"
+// @Param end_date query string false "End date, inclusive (YYYY-MM-DD)"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (default 50, max 500)"
+// @Param sort query string false "Sort fields, comma separated, prefix - for descending (id, entity, action, created_at)"
+// @Success 200 {array} models.AuditLog "List of audit log entries"
+// @Header 200 {integer} X-Total-Count "Total entries matching the filter"
+// @Failure 400 {object} map[string]string "Invalid filter, pagination or sort"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin or manager role)"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /audit [get]
+func (ctrl *AuditController) GetAll(c *gin.Context) {
+	pagination, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sortClause, err := parseSort(c, auditSortFields, "created_at DESC")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	dateRange, err := parseDateRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := ctrl.DB.Model(&models.AuditLog{}).Scopes(storeScope(c, "store_id"))
+	for _, column := range []string{"entity", "action", "actor_type", "request_id"} {
+		if value := c.Query(column); value != "" {
+			query = query.Where(column+" = ?", value)
+		}
+	}
+	for _, column := range []string{"entity_id", "actor_id"} {
+		if value := c.Query(column); value != "" {
+			id, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + column + " (must be number)"})
+				return
+			}
+			query = query.Where(column+" = ?", id)
+		}
+	}
+	if dateRange.Start != nil {
+		query = query.Where("created_at >= ?", *dateRange.Start)
+	}
+	if dateRange.End != nil {
+		query = query.Where("created_at < ?", *dateRange.End)
+	}
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	var logs []models.AuditLog
+	if err := query.Order(sortClause).Order("id DESC").Offset(pagination.Offset()).Limit(pagination.Limit).
+		Find(&logs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	setPaginationHeaders(c, pagination, total)
+	c.JSON(http.StatusOK, logs)
+}
@@ -0,0 +1,316 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"backend-penjualan/auth"
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AuthController godoc
+// @Description Auth controller handles login, token refresh and logout (JWT access token + rotating refresh token)
+type AuthController struct {
+	DB   *gorm.DB
+	Auth auth.Config
+}
+
+func NewAuthController(db *gorm.DB, cfg auth.Config) *AuthController {
+	return &AuthController{DB: db, Auth: cfg}
+}
+
+// LoginInput body login
+type LoginInput struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// RefreshInput body refresh/logout
+type RefreshInput struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// ChangePasswordInput body ganti password sendiri
+type ChangePasswordInput struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+// TokenResponse godoc
+// @Description Access token (send as "Authorization: Bearer <access_token>") plus refresh token for POST /auth/refresh
+type TokenResponse struct {
+	AccessToken      string      `json:"access_token"`
+	TokenType        string      `json:"token_type" example:"Bearer"`
+	ExpiresIn        int         `json:"expires_in"` // Detik sampai access token expired
+	RefreshToken     string      `json:"refresh_token"`
+	RefreshExpiresAt time.Time   `json:"refresh_expires_at"`
+	User             models.User `json:"user"`
+}
+
+var errInvalidRefreshToken = errors.New("Invalid or expired refresh token")
+
+// issueTokens buat access token + refresh token baru (disimpan di tx)
+func (ctrl *AuthController) issueTokens(tx *gorm.DB, c *gin.Context, user models.User) (*TokenResponse, error) {
+	accessToken, accessExpiresAt, err := ctrl.Auth.IssueAccessToken(user)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, refreshHash, err := auth.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	session := models.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: refreshHash,
+		ExpiresAt: time.Now().Add(ctrl.Auth.RefreshTTL),
+		UserAgent: truncate(c.Request.UserAgent(), 255),
+	}
+	if err := tx.Create(&session).Error; err != nil {
+		return nil, err
+	}
+	return &TokenResponse{
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(time.Until(accessExpiresAt).Seconds()),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: session.ExpiresAt,
+		User:             user,
+	}, nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+// revokeUserSessions revoke semua refresh token aktif milik user (logout dari semua device)
+func revokeUserSessions(tx *gorm.DB, userID uint) error {
+	return tx.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// Login godoc
+// @Summary Log in
+// @Description Log in with username & password. Returns a short-lived JWT access token (JWT_ACCESS_TTL, default 15m) and a refresh token (JWT_REFRESH_TTL, default 7 days).
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body LoginInput true "Username & password"
+// @Success 200 {object} controllers.TokenResponse
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 401 {object} map[string]string "Invalid username or password"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auth/login [post]
+func (ctrl *AuthController) Login(c *gin.Context) {
+	var input LoginInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var user models.User
+	err := ctrl.DB.Where("lower(username) = ?", strings.ToLower(strings.TrimSpace(input.Username))).First(&user).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	// Pesan error sama untuk user gak ada / password salah / nonaktif biar username gak bisa ditebak
+	if err != nil || !user.Active || !auth.CheckPassword(user.PasswordHash, input.Password) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid username or password"})
+		return
+	}
+
+	var resp *TokenResponse
+	err = ctrl.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Model(&user).UpdateColumn("last_login_at", now).Error; err != nil {
+			return err
+		}
+		user.LastLoginAt = &now
+		resp, err = ctrl.issueTokens(tx, c, user)
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Refresh godoc
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access token. The refresh token is rotated: the old one is revoked and a new one returned. Reusing a revoked refresh token revokes all sessions of that user.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body RefreshInput true "Refresh token"
+// @Success 200 {object} controllers.TokenResponse
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 401 {object} map[string]string "Invalid or expired refresh token"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auth/refresh [post]
+func (ctrl *AuthController) Refresh(c *gin.Context) {
+	var input RefreshInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var session models.RefreshToken
+	if err := ctrl.DB.Where("token_hash = ?", auth.HashToken(input.RefreshToken)).First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": errInvalidRefreshToken.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	if session.RevokedAt != nil {
+		// Token lama dipakai lagi -> kemungkinan bocor, putus semua sesi user ini
+		if err := revokeUserSessions(ctrl.DB, session.UserID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": errInvalidRefreshToken.Error()})
+		return
+	}
+	if time.Now().After(session.ExpiresAt) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": errInvalidRefreshToken.Error()})
+		return
+	}
+
+	var resp *TokenResponse
+	err := ctrl.DB.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.First(&user, session.UserID).Error; err != nil || !user.Active {
+			return errInvalidRefreshToken
+		}
+		// Rotasi: revoke token lama (cek revoked_at biar dua refresh barengan gak sama-sama sukses)
+		result := tx.Model(&session).Where("revoked_at IS NULL").Update("revoked_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errInvalidRefreshToken
+		}
+		var err error
+		resp, err = ctrl.issueTokens(tx, c, user)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, errInvalidRefreshToken) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Logout godoc
+// @Summary Log out
+// @Description Revoke a refresh token. The access token stays valid until it expires (JWT_ACCESS_TTL), so clients should discard it.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body RefreshInput true "Refresh token to revoke"
+// @Success 200 {object} map[string]string "Logged out"
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auth/logout [post]
+func (ctrl *AuthController) Logout(c *gin.Context) {
+	var input RefreshInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// Token gak dikenal / sudah revoke tetap dianggap sukses (idempotent)
+	if err := ctrl.DB.Model(&models.RefreshToken{}).
+		Where("token_hash = ? AND revoked_at IS NULL", auth.HashToken(input.RefreshToken)).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
+}
+
+// Me godoc
+// @Summary Get current user
+// @Description Profile of the logged-in user
+// @Tags auth
+// @Produce json
+// @Success 200 {object} models.User
+// @Failure 401 {object} map[string]string "Not logged in or user no longer active"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auth/me [get]
+func (ctrl *AuthController) Me(c *gin.Context) {
+	claims, _ := auth.CurrentUser(c)
+	var user models.User
+	if err := ctrl.DB.First(&user, claims.UserID()).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User no longer exists"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	if !user.Active {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User is not active"})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// ChangePassword godoc
+// @Summary Change own password
+// @Description Change the password of the logged-in user. All refresh tokens of the user are revoked, so other devices must log in again.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body ChangePasswordInput true "Current & new password (min. 8 characters)"
+// @Success 200 {object} map[string]string "Password changed"
+// @Failure 400 {object} map[string]string "Invalid input or new password too short"
+// @Failure 401 {object} map[string]string "Current password is wrong"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auth/password [put]
+func (ctrl *AuthController) ChangePassword(c *gin.Context) {
+	var input ChangePasswordInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	claims, _ := auth.CurrentUser(c)
+	var user models.User
+	if err := ctrl.DB.First(&user, claims.UserID()).Error; err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User no longer exists"})
+		return
+	}
+	if !auth.CheckPassword(user.PasswordHash, input.CurrentPassword) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Current password is wrong"})
+		return
+	}
+	hash, err := auth.HashPassword(input.NewPassword)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	err = ctrl.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&user).Update("password_hash", hash).Error; err != nil {
+			return err
+		}
+		return revokeUserSessions(tx, user.ID)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Password changed"})
+}
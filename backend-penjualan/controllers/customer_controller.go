@@ -0,0 +1,366 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CustomerController godoc
+// @Description Customer controller handles CRUD operations for customers and their purchase history
+type CustomerController struct {
+	DB *gorm.DB
+}
+
+func NewCustomerController(db *gorm.DB) *CustomerController {
+	return &CustomerController{DB: db}
+}
+
+var (
+	errCustomerNotFound     = errors.New("customer not found")
+	errNamaPembeliRequired  = errors.New("nama pembeli required")
+	errInvalidCustomerInput = errors.New("invalid customer input")
+)
+
+// customerSortFields whitelist field yang boleh dipakai di ?sort=
+var customerSortFields = map[string]string{
+	"id":         "id",
+	"nama":       "nama",
+	"email":      "email",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// CustomerInput body buat create/update customer (update: field kosong/null gak diubah)
+type CustomerInput struct {
+	Nama    *string `json:"nama"`
+	Telepon *string `json:"telepon"`
+	Email   *string `json:"email"`
+	Alamat  *string `json:"alamat"`
+}
+
+// CustomerTransactionsResponse riwayat belanja customer + lifetime spend
+type CustomerTransactionsResponse struct {
+	Customer         models.Customer      `json:"customer"`
+	Transactions     []models.Transaction `json:"transactions"`
+	Orders           []models.Order       `json:"orders"`
+	TransactionCount int64                `json:"transaction_count"`
+	OrderCount       int64                `json:"order_count"`
+	TotalQuantity    uint                 `json:"total_quantity"`
+	LifetimeSpend    float64              `json:"lifetime_spend"`
+}
+
+// resolveCustomer dipakai transactions & orders: kalau customer_id diisi, pastikan customer ada
+// dan pakai namanya sebagai nama_pembeli (kalau nama_pembeli kosong). Walk-in cukup nama_pembeli.
+func resolveCustomer(db *gorm.DB, customerID *int64, namaPembeli string) (*uint, string, error) {
+	namaPembeli = strings.TrimSpace(namaPembeli)
+	if customerID == nil {
+		if namaPembeli == "" {
+			return nil, "", errNamaPembeliRequired
+		}
+		return nil, namaPembeli, nil
+	}
+	if *customerID <= 0 {
+		return nil, "", errCustomerNotFound
+	}
+	var customer models.Customer
+	if err := db.First(&customer, uint(*customerID)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", errCustomerNotFound
+		}
+		return nil, "", err
+	}
+	if namaPembeli == "" {
+		namaPembeli = customer.Nama
+	}
+	id := customer.ID
+	return &id, namaPembeli, nil
+}
+
+// respondCustomerError mapping error dari resolveCustomer ke HTTP response
+func respondCustomerError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errNamaPembeliRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Nama pembeli or customer_id required"})
+	case errors.Is(err, errCustomerNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Customer not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Customer query failed: %v", err.Error())})
+	}
+}
+
+// applyCustomerInput validasi & copy field input ke customer (trim spasi biar "budi " == "budi")
+func applyCustomerInput(customer *models.Customer, input CustomerInput) error {
+	if input.Nama != nil {
+		nama := strings.TrimSpace(*input.Nama)
+		if nama == "" {
+			return fmt.Errorf("%w: nama required", errInvalidCustomerInput)
+		}
+		customer.Nama = nama
+	}
+	if input.Telepon != nil {
+		telepon := strings.TrimSpace(*input.Telepon)
+		for _, r := range telepon {
+			if !strings.ContainsRune("0123456789+- ", r) {
+				return fmt.Errorf("%w: telepon hanya boleh angka, +, - dan spasi", errInvalidCustomerInput)
+			}
+		}
+		customer.Telepon = telepon
+	}
+	if input.Email != nil {
+		email := strings.ToLower(strings.TrimSpace(*input.Email))
+		if email != "" {
+			if _, err := mail.ParseAddress(email); err != nil {
+				return fmt.Errorf("%w: email tidak valid", errInvalidCustomerInput)
+			}
+		}
+		customer.Email = email
+	}
+	if input.Alamat != nil {
+		customer.Alamat = strings.TrimSpace(*input.Alamat)
+	}
+	return nil
+}
+
+// GetAll godoc
+// @Summary Get all customers
+// @Description Retrieve paginated list of customers with optional search by name, phone or email. Total rows returned in X-Total-Count header.
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param search query string false "Search by name, phone or email (partial)"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (max 500; default 50 when page is set, all rows when neither page nor limit is set)"
+// @Param sort query string false "Sort fields, comma separated, prefix - for descending (id, nama, email, created_at, updated_at)"
+// @Success 200 {array} models.Customer "List of customers"
+// @Header 200 {integer} X-Total-Count "Total customers matching the filter"
+// @Failure 400 {object} map[string]string "Invalid pagination or sort"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /customers [get]
+func (ctrl *CustomerController) GetAll(c *gin.Context) {
+	pagination, err := parseLegacyPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sortClause, err := parseSort(c, customerSortFields, "id ASC")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var customers []models.Customer
+	query := ctrl.DB.Model(&models.Customer{})
+	if search := strings.TrimSpace(c.Query("search")); search != "" {
+		like := "%" + search + "%"
+		query = query.Where("nama ILIKE ? OR telepon ILIKE ? OR email ILIKE ?", like, like, like)
+	}
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := query.Order(sortClause).Order("id").Offset(pagination.Offset()).Limit(pagination.Limit).
+		Find(&customers).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	setPaginationHeaders(c, pagination, total)
+	c.JSON(http.StatusOK, customers)
+}
+
+// GetByID godoc
+// @Summary Get customer by ID
+// @Description Retrieve a specific customer by ID
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Success 200 {object} models.Customer "Customer details"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 404 {object} map[string]string "Customer not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /customers/{id} [get]
+func (ctrl *CustomerController) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var customer models.Customer
+	if err := ctrl.DB.First(&customer, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Customer not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, customer)
+}
+
+// Create godoc
+// @Summary Create a new customer
+// @Description Create a new customer (nama required, email & telepon validated)
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param customer body CustomerInput true "Customer data"
+// @Success 201 {object} models.Customer "Created customer"
+// @Failure 400 {object} map[string]string "Validation error"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /customers [post]
+func (ctrl *CustomerController) Create(c *gin.Context) {
+	var input CustomerInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Nama == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Nama required"})
+		return
+	}
+	var customer models.Customer
+	if err := applyCustomerInput(&customer, input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := ctrl.DB.Create(&customer).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, customer)
+}
+
+// Update godoc
+// @Summary Update a customer
+// @Description Update a customer by ID (only provided fields are changed)
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Param customer body CustomerInput true "Fields to update"
+// @Success 200 {object} models.Customer "Updated customer"
+// @Failure 400 {object} map[string]string "Invalid ID or validation error"
+// @Failure 404 {object} map[string]string "Customer not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /customers/{id} [put]
+func (ctrl *CustomerController) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var customer models.Customer
+	if err := ctrl.DB.First(&customer, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Customer not found"})
+		return
+	}
+	var input CustomerInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := applyCustomerInput(&customer, input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := ctrl.DB.Save(&customer).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, customer)
+}
+
+// Delete godoc
+// @Summary Delete a customer
+// @Description Soft delete a customer by ID. Linked transactions & orders keep their customer_id and still show the customer; a deleted customer can no longer be used for new transactions or orders.
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Success 200 {object} map[string]string "Success message"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 404 {object} map[string]string "Customer not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin or manager role)"
+// @Router /customers/{id} [delete]
+func (ctrl *CustomerController) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	result := ctrl.DB.Delete(&models.Customer{}, id)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Customer not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Customer deleted"})
+}
+
+// Transactions godoc
+// @Summary Get customer purchase history
+// @Description Retrieve all transactions & orders of a customer with lifetime spend
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Success 200 {object} CustomerTransactionsResponse "Purchase history"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 404 {object} map[string]string "Customer not found"
+// @Failure 500 {object} map[string]string "Query failed"
+// @Router /customers/{id}/transactions [get]
+func (ctrl *CustomerController) Transactions(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var resp CustomerTransactionsResponse
+	if err := ctrl.DB.First(&resp.Customer, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Customer not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		}
+		return
+	}
+
+	if err := ctrl.DB.Scopes(storeScope(c, "store_id")).Preload("Product", withDeleted).Where("customer_id = ?", id).
+		Order("created_at DESC").Find(&resp.Transactions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		return
+	}
+	if err := ctrl.DB.Scopes(storeScope(c, "store_id")).Preload("Items.Product", withDeleted).Where("customer_id = ?", id).
+		Order("created_at DESC").Find(&resp.Orders).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		return
+	}
+
+	resp.TransactionCount = int64(len(resp.Transactions))
+	resp.OrderCount = int64(len(resp.Orders))
+	for _, t := range resp.Transactions {
+		resp.TotalQuantity += t.Quantity
+		resp.LifetimeSpend += t.Total
+	}
+	for _, o := range resp.Orders {
+		resp.TotalQuantity += o.TotalQuantity
+		resp.LifetimeSpend += o.Total
+	}
+	c.JSON(http.StatusOK, resp)
+}
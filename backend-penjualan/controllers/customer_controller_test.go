@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCustomerDeleteIsSoftDelete(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantStatus   int
+	}{
+		{"existing customer", 1, http.StatusOK},
+		{"missing or already deleted", 0, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeDB(t, func(string) fakeResult { return fakeResult{rowsAffected: tt.rowsAffected} })
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodDelete, "/customers/5", nil)
+			c.Params = gin.Params{{Key: "id", Value: "5"}}
+
+			NewCustomerController(fake.db).Delete(c)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if deletes := fake.Matching("DELETE"); len(deletes) > 0 {
+				t.Errorf("customer hard deleted: %q", deletes)
+			}
+			if updates := fake.Matching(`UPDATE "customers" SET "deleted_at"=`, `"customers"."id" = 5`, `"customers"."deleted_at" IS NULL`); len(updates) != 1 {
+				t.Errorf("statements = %q, want one soft delete update", fake.Statements())
+			}
+		})
+	}
+}
+
+func TestResolveCustomerIgnoresDeletedCustomers(t *testing.T) {
+	fake := newFakeDB(t, func(string) fakeResult {
+		return fakeResult{columns: []string{"id", "nama"}} // Gak ada customer aktif dengan id ini
+	})
+	id := int64(5)
+	if _, _, err := resolveCustomer(fake.db, &id, ""); err != errCustomerNotFound {
+		t.Fatalf("resolveCustomer() error = %v, want errCustomerNotFound", err)
+	}
+	if lookups := fake.Matching(`FROM "customers"`, `"customers"."deleted_at" IS NULL`); len(lookups) != 1 {
+		t.Errorf("statements = %q, want lookup limited to customers that are not deleted", fake.Statements())
+	}
+}
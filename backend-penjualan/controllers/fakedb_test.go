@@ -0,0 +1,171 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeResult jawaban satu statement: rows buat SELECT/RETURNING, rowsAffected buat exec
+type fakeResult struct {
+	columns      []string
+	rows         [][]driver.Value
+	rowsAffected int64
+	err          error
+}
+
+// fakeHandler jawab statement berdasarkan SQL-nya (nilai parameter sudah diisi, lihat Dialector.Explain)
+type fakeHandler func(sql string) fakeResult
+
+// fakeDB database/sql palsu di belakang dialect postgres: gak butuh server, tiap statement dicatat
+// berurutan (termasuk BEGIN/COMMIT/ROLLBACK) dan dijawab handler.
+type fakeDB struct {
+	mu         sync.Mutex
+	db         *gorm.DB
+	handler    fakeHandler
+	statements []string
+}
+
+func newFakeDB(t *testing.T, handler fakeHandler) *fakeDB {
+	t.Helper()
+	f := &fakeDB{handler: handler}
+	if f.handler == nil {
+		f.handler = func(string) fakeResult { return fakeResult{} }
+	}
+	sqlDB := sql.OpenDB(fakeConnector{f})
+	t.Cleanup(func() { sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("open fake db: %v", err)
+	}
+	f.db = db
+	return f
+}
+
+// Statements SQL yang sudah dijalankan
+func (f *fakeDB) Statements() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.statements...)
+}
+
+// Matching statement yang mengandung semua potongan parts
+func (f *fakeDB) Matching(parts ...string) []string {
+	var matched []string
+	for _, s := range f.Statements() {
+		if containsAll(s, parts...) {
+			matched = append(matched, s)
+		}
+	}
+	return matched
+}
+
+func containsAll(s string, parts ...string) bool {
+	for _, part := range parts {
+		if !strings.Contains(s, part) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *fakeDB) run(query string, args []driver.NamedValue) fakeResult {
+	vars := make([]interface{}, len(args))
+	for i, arg := range args {
+		vars[i] = arg.Value
+	}
+	rendered := f.db.Dialector.Explain(query, vars...)
+	f.mu.Lock()
+	f.statements = append(f.statements, rendered)
+	f.mu.Unlock()
+	return f.handler(rendered)
+}
+
+func (f *fakeDB) record(statement string) {
+	f.mu.Lock()
+	f.statements = append(f.statements, statement)
+	f.mu.Unlock()
+}
+
+type fakeConnector struct{ db *fakeDB }
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return &fakeConn{c.db}, nil }
+func (c fakeConnector) Driver() driver.Driver                        { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return nil, driver.ErrSkip }
+
+type fakeConn struct{ db *fakeDB }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	c.db.record("BEGIN")
+	return fakeTx{c.db}, nil
+}
+
+// CheckNamedValue terima semua tipe argumen apa adanya
+func (c *fakeConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	result := c.db.run(query, args)
+	if result.err != nil {
+		return nil, result.err
+	}
+	return &fakeRows{columns: result.columns, rows: result.rows}, nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	result := c.db.run(query, args)
+	if result.err != nil {
+		return nil, result.err
+	}
+	return driver.RowsAffected(result.rowsAffected), nil
+}
+
+type fakeTx struct{ db *fakeDB }
+
+func (tx fakeTx) Commit() error   { tx.db.record("COMMIT"); return nil }
+func (tx fakeTx) Rollback() error { tx.db.record("ROLLBACK"); return nil }
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+	next    int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
+
+// productRow satu row products buat jawaban SELECT
+func productRow(id, storeID uint, nama string, harga float64, stok int) fakeResult {
+	return fakeResult{
+		columns: []string{"id", "store_id", "nama", "harga", "stok"},
+		rows:    [][]driver.Value{{int64(id), int64(storeID), nama, harga, int64(stok)}},
+	}
+}
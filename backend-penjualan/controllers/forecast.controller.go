@@ -2,132 +2,467 @@ package controllers
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
+	"errors"
+	"fmt"
 	"io"
-	"mime/multipart"
+	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
+	"backend-penjualan/auth"
+	"backend-penjualan/cache"
+	"backend-penjualan/forecasting"
+	"backend-penjualan/jobs"
+	"backend-penjualan/mlclient"
+
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"gorm.io/gorm"
 )
 
 // ForecastResponse godoc
-// @Description Response for forecast upload (includes historical + predictions)
+// @Description Forecast result: historical points (actual + fitted yhat) and forecast points (yhat with 95% interval). trend/weekly/yearly components are included when the model provides them (Prophet; Holt-Winters gives trend + weekly).
 type ForecastResponse struct {
-	Historical []map[string]interface{} `json:"historical"`
-	Forecast   []map[string]interface{} `json:"forecast"`
+	RunID          uint                      `json:"run_id,omitempty"`          // ID forecast run yang tersimpan
+	Model          string                    `json:"model,omitempty"`           // Model yang benar-benar dipakai
+	FallbackReason string                    `json:"fallback_reason,omitempty"` // Diisi kalau ML down & pakai engine native
+	Historical     []forecasting.ResultPoint `json:"historical"`                // Actual + fitted value per hari
+	Forecast       []forecasting.ResultPoint `json:"forecast"`                  // Prediksi + interval kepercayaan per hari
 }
 
 type UploadForecastController struct {
-	// Config buat FastAPI URL (dari env atau hardcode)
-	FastAPIURL string
+	DB *gorm.DB
+	// Client ke ML service (FastAPI); config dari env ML_SERVICE_URL, ML_TIMEOUT, ML_MAX_RETRIES, ML_BREAKER_*
+	MLClient mlclient.Client
+	ML       *forecasting.MLService
+	Jobs     *jobs.Pool // Worker pool buat POST /forecast/jobs (di-Start dari router)
+	// Interval evaluasi akurasi forecast run vs penjualan aktual (FORECAST_EVAL_INTERVAL, default 6h)
+	EvalInterval time.Duration
+	// Cache hasil forecast (nil kalau FORECAST_CACHE_TTL=0)
+	Cache      *cache.Cache
+	cacheStore *cache.PostgresStore
+}
+
+func NewForecastController(db *gorm.DB) *UploadForecastController {
+	return NewForecastControllerWithClient(db, mlclient.New(mlclient.ConfigFromEnv()))
+}
+
+// NewForecastControllerWithClient sama dengan NewForecastController tapi ML client-nya bisa diganti (mis. fake buat test)
+func NewForecastControllerWithClient(db *gorm.DB, client mlclient.Client) *UploadForecastController {
+	evalInterval := 6 * time.Hour
+	if d, err := time.ParseDuration(os.Getenv("FORECAST_EVAL_INTERVAL")); err == nil && d > 0 {
+		evalInterval = d
+	}
+	ctrl := &UploadForecastController{
+		DB:           db,
+		MLClient:     client,
+		ML:           forecasting.NewMLService(client),
+		EvalInterval: evalInterval,
+	}
+	ctrl.Cache, ctrl.cacheStore = newForecastCache(db)
+	ctrl.Jobs = jobs.NewPool(db, ctrl.processJob)
+	return ctrl
+}
+
+// Health godoc
+// @Summary ML service health
+// @Description Ping the ML service health endpoint (ML_HEALTH_URL, default <ML_SERVICE_URL base>/health) and report latency plus the circuit breaker state (closed, open, half_open). Returns 503 when the ML service is down; forecasts with model auto still work through the native fallback.
+// @Tags forecast
+// @Produce json
+// @Success 200 {object} mlclient.Health "ML service up"
+// @Failure 503 {object} mlclient.Health "ML service down"
+// @Router /api/v1/forecast/health [get]
+func (ctrl *UploadForecastController) Health(c *gin.Context) {
+	health := ctrl.MLClient.Health(c.Request.Context())
+	status := http.StatusOK
+	if health.Status != "up" {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, health)
+}
+
+// TransactionForecastInput body buat forecast langsung dari tabel transactions
+type TransactionForecastInput struct {
+	ProductID *uint  `json:"product_id"` // Kosong = semua produk
+	StartDate string `json:"start_date"` // YYYY-MM-DD, optional
+	EndDate   string `json:"end_date"`   // YYYY-MM-DD, optional
+	Periods   int    `json:"periods"`    // Default 30, max 365
+	Model     string `json:"model"`      // auto (default), prophet, holt_winters, moving_average
+}
+
+// forecastInput semua yang dibutuhkan satu forecast run (series sudah dinormalisasi)
+type forecastInput struct {
+	Source    string
+	Filename  string
+	InputHash string
+	ProductID *uint
+	StoreID   *uint // Toko pemilik run/job; null = kantor pusat
+	Model     string
+	Periods   int
+	Series    []forecasting.Point
+	Holidays  []forecasting.Holiday // Diisi withHolidays sebelum forecast
+}
+
+// respondForecastError mapping error forecast ke HTTP response (format ML error sama dengan sebelumnya)
+func respondForecastError(c *gin.Context, err error) {
+	var mlErr *forecasting.MLServiceError
+	switch {
+	case errors.Is(err, forecasting.ErrUnknownModel), errors.Is(err, forecasting.ErrInsufficientData):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.As(err, &mlErr):
+		body := gin.H{"error": mlErr.Message, "details": mlErr.Details}
+		if mlErr.Status != 0 {
+			body["status"] = mlErr.Status
+		}
+		if mlErr.Raw != "" {
+			body["raw"] = mlErr.Raw
+		}
+		c.JSON(http.StatusInternalServerError, body)
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// respondCSVError 413 kalau file kebesaran, 400 + laporan per baris kalau isinya gak valid
+func respondCSVError(c *gin.Context, err error) {
+	var csvErr *forecasting.CSVError
+	switch {
+	case errors.Is(err, forecasting.ErrFileTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.As(err, &csvErr):
+		c.JSON(http.StatusBadRequest, csvErr)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+// withHolidays isi holiday dari kalender yang mengenai series + horizon. Gagal load cuma di-log,
+// forecast tetap jalan tanpa regressor holiday.
+func (ctrl *UploadForecastController) withHolidays(ctx context.Context, in forecastInput) forecastInput {
+	holidays, err := loadHolidays(ctrl.DB.WithContext(ctx), in.Series, in.Periods)
+	if err != nil {
+		log.Printf("Warning: failed to load holidays: %v", err)
+		return in
+	}
+	in.Holidays = holidays
+	return in
+}
+
+// runForecast pilih forecaster sesuai model (default auto: ML + fallback native), jalankan,
+// lalu simpan hasilnya (sukses maupun gagal) sebagai forecast run
+func (ctrl *UploadForecastController) runForecast(ctx context.Context, in forecastInput) (*ForecastResponse, error) {
+	resp, err := ctrl.forecast(ctx, in)
+	// Input invalid (model gak dikenal, data kurang) gak perlu dicatat sebagai run
+	if errors.Is(err, forecasting.ErrUnknownModel) || errors.Is(err, forecasting.ErrInsufficientData) {
+		return nil, err
+	}
+	if run := ctrl.saveForecastRun(in, resp, err); run != nil && resp != nil {
+		resp.RunID = run.ID
+	}
+	return resp, err
 }
 
-func NewForecastController() *UploadForecastController {
-	fastAPIURL := os.Getenv("ML_SERVICE_URL")
-	if fastAPIURL == "" {
-		fastAPIURL = "http://localhost:8000/predict" // Default
+func (ctrl *UploadForecastController) forecast(ctx context.Context, in forecastInput) (*ForecastResponse, error) {
+	forecaster, err := forecasting.New(in.Model, ctrl.ML)
+	if err != nil {
+		return nil, err
 	}
-	return &UploadForecastController{
-		FastAPIURL: fastAPIURL,
+	result, err := forecaster.Forecast(ctx, forecasting.Request{Series: in.Series, Periods: in.Periods, Holidays: in.Holidays})
+	if err != nil {
+		return nil, err
 	}
+	return &ForecastResponse{
+		Model:          result.Model,
+		FallbackReason: result.FallbackReason,
+		Historical:     result.Historical,
+		Forecast:       result.Forecast,
+	}, nil
 }
 
 // UploadHandler godoc
-// @Summary Upload CSV and get forecast
-// @Description Upload CSV for sales forecast using Prophet model. CSV must have 'date' (YYYY-MM-DD) and 'projected_quantity' (or 'value') columns.
+// @Summary Upload CSV/XLSX or JSON points and get forecast
+// @Description Forecast from an uploaded file or a JSON body. Multipart: CSV or XLSX file (max 5 MB) in 'csvFile' (or 'file'); format is taken from the 'format' field or the file extension. CSV delimiter (, ; tab or |) is detected automatically. For XLSX pick the sheet with 'sheet' (default first sheet); dates may be Excel date cells or YYYY-MM-DD text. Columns default to 'date' and 'projected_quantity' (or 'value'); choose others with 'date_column'/'value_column' (header name or column letter). JSON: {"points": [{"date": "2025-01-01", "value": 12}], "periods": 30, "model": "auto"}. All inputs are validated the same way (unique YYYY-MM-DD dates, non-negative numbers) and rejected with a row-by-row report, then normalized into the same date/projected_quantity series sent to the ML service. Default model 'auto' uses the ML service (Prophet) and falls back to the native Go engine when the ML service is down. Every run is stored (see /forecast/runs). Results are cached by input hash + periods + model (X-Cache header).
 // @Tags forecast
-// @Accept multipart/form-data
+// @Accept multipart/form-data,json
 // @Produce json
-// @Param csvFile formData file true "CSV file with historical data"
-// @Param periods formData int false "Number of days to forecast (default 30)"
+// @Param csvFile formData file false "CSV or XLSX file with historical data (alias: file)"
+// @Param format formData string false "File format (default from extension, else csv)" Enums(csv, xlsx)
+// @Param sheet formData string false "XLSX sheet name (default first sheet)"
+// @Param date_column formData string false "Date column: header name or column letter (default date)"
+// @Param value_column formData string false "Value column: header name or column letter (default projected_quantity or value)"
+// @Param periods formData int false "Number of days to forecast (default 30, max 365)"
+// @Param model formData string false "Forecast model (default auto)" Enums(auto, prophet, holt_winters, moving_average)
+// @Param input body PointsForecastInput false "Forecast from JSON points (instead of multipart)"
 // @Success 200 {object} controllers.ForecastResponse
-// @Failure 400 {object} map[string]string "Invalid CSV or input"
+// @Failure 400 {object} forecasting.CSVError "Invalid file/points (row-by-row report) or input"
+// @Failure 413 {object} map[string]string "File too large (max 5 MB)"
 // @Failure 500 {object} map[string]string "Server error (e.g., ML service failed)"
+// @Param no_cache query bool false "Skip cached result and forecast again (result is re-cached)"
+// @Header 200 {string} X-Cache "HIT, MISS or BYPASS"
 // @Router /api/v1/forecast/upload [post]
 func (ctrl *UploadForecastController) UploadHandler(c *gin.Context) {
-	// Parse multipart
-	file, err := c.FormFile("csvFile")
+	var (
+		in forecastInput
+		ok bool
+	)
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		in, ok = ctrl.uploadInput(c)
+	} else {
+		in, ok = ctrl.pointsInput(c)
+	}
+	if !ok {
+		return
+	}
+	forecastResp, err := ctrl.cachedForecast(c, in)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No CSV file provided"})
+		respondForecastError(c, err)
 		return
 	}
+	c.JSON(http.StatusOK, forecastResp)
+}
 
-	// Periods dari form
-	periodsStr := c.PostForm("periods")
-	periods, _ := strconv.Atoi(periodsStr)
+// PointsForecastInput body JSON buat forecast dari titik {date, value} (tanpa upload file)
+type PointsForecastInput struct {
+	Points  []forecasting.InputPoint `json:"points"`  // Series harian, tanggal YYYY-MM-DD unik
+	Periods int                      `json:"periods"` // Default 30, max 365
+	Model   string                   `json:"model"`   // auto (default), prophet, holt_winters, moving_average
+}
+
+// resolvePeriods periods dari input: 0 (gak diisi) = default 30, selain itu wajib 1 sampai MaxPeriods
+func resolvePeriods(periods int) (int, error) {
 	if periods == 0 {
-		periods = 30
+		return forecasting.DefaultPeriods, nil
+	}
+	if err := forecasting.ValidatePeriods(periods); err != nil {
+		return 0, fmt.Errorf("Invalid periods (must be between 1 and %d)", forecasting.MaxPeriods)
 	}
+	return periods, nil
+}
+
+// parsePeriods sama dengan resolvePeriods tapi dari field form (kosong = default, bukan angka = error)
+func parsePeriods(raw string) (int, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return forecasting.DefaultPeriods, nil
+	}
+	periods, err := strconv.Atoi(raw)
+	if err != nil || periods == 0 {
+		return 0, fmt.Errorf("Invalid periods (must be between 1 and %d)", forecasting.MaxPeriods)
+	}
+	return resolvePeriods(periods)
+}
 
-	// Buat multipart form buat FastAPI
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-	part, err := writer.CreateFormFile("csv_file", file.Filename) // FastAPI expect "csv_file"
+// uploadFormats format file yang diterima di multipart upload
+var uploadFormats = map[string]bool{"csv": true, "xlsx": true}
+
+// uploadInput baca multipart csvFile (atau file)/format/sheet/date_column/value_column/periods/model jadi forecastInput.
+// Kalau invalid, response error sudah ditulis & return false.
+func (ctrl *UploadForecastController) uploadInput(c *gin.Context) (forecastInput, bool) {
+	// Parse multipart
+	file, err := c.FormFile("csvFile")
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create form"})
-		return
+		file, err = c.FormFile("file")
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No CSV or XLSX file provided"})
+		return forecastInput{}, false
+	}
+
+	// Format dari field 'format', kalau kosong dari ekstensi file
+	format := strings.ToLower(strings.TrimSpace(c.PostForm("format")))
+	if format == "" {
+		format = "csv"
+		if strings.EqualFold(filepath.Ext(file.Filename), ".xlsx") {
+			format = "xlsx"
+		}
+	}
+	if !uploadFormats[format] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format (must be csv or xlsx)"})
+		return forecastInput{}, false
+	}
+
+	// Periods dari form
+	periods, err := parsePeriods(c.PostForm("periods"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return forecastInput{}, false
+	}
+
+	if file.Size > forecasting.MaxUploadBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": forecasting.ErrFileTooLarge.Error()})
+		return forecastInput{}, false
 	}
 	f, err := file.Open()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open file"})
-		return
+		return forecastInput{}, false
 	}
 	defer f.Close()
-	io.Copy(part, f)
-
-	// Tambah periods
-	writer.WriteField("periods", strconv.Itoa(periods))
-	writer.Close()
+	data, err := io.ReadAll(io.LimitReader(f, forecasting.MaxUploadBytes+1))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
+		return forecastInput{}, false
+	}
 
-	// HTTP POST ke FastAPI
-	req, err := http.NewRequest("POST", ctrl.FastAPIURL, body)
+	// Validasi & parse file di Go dulu; ML service cuma terima series yang sudah dinormalisasi
+	columns := forecasting.TableOptions{
+		DateColumn:  c.PostForm("date_column"),
+		ValueColumn: c.PostForm("value_column"),
+	}
+	var series []forecasting.Point
+	if format == "xlsx" {
+		series, err = forecasting.ParseXLSX(bytes.NewReader(data), forecasting.XLSXOptions{
+			Sheet:        c.PostForm("sheet"),
+			TableOptions: columns,
+		})
+	} else {
+		series, err = forecasting.ParseCSVWithOptions(bytes.NewReader(data), columns)
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create request"})
-		return
+		respondCSVError(c, err)
+		return forecastInput{}, false
 	}
-	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	// Hash dari isi file + pilihan sheet/kolom (sheet lain = input lain)
+	hashData := data
+	if options := c.PostForm("sheet") + "|" + columns.DateColumn + "|" + columns.ValueColumn; options != "||" {
+		hashData = append(append([]byte{}, data...), options...)
+	}
+	return forecastInput{
+		Source:    forecastSourceUpload,
+		Filename:  file.Filename,
+		InputHash: hashInput(hashData),
+		StoreID:   auth.CurrentStoreID(c),
+		Model:     c.PostForm("model"),
+		Periods:   periods,
+		Series:    series,
+	}, true
+}
+
+// pointsInput baca body PointsForecastInput jadi forecastInput (hash = CSV ternormalisasi).
+// Kalau invalid, response error sudah ditulis & return false.
+func (ctrl *UploadForecastController) pointsInput(c *gin.Context) (forecastInput, bool) {
+	var input PointsForecastInput
+	if err := c.ShouldBindBodyWith(&input, binding.JSON); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid input: %v", err)})
+		return forecastInput{}, false
+	}
+	periods, err := resolvePeriods(input.Periods)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "ML service unreachable",
-			"details": err.Error(),
-		})
-		return
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return forecastInput{}, false
+	}
+	input.Periods = periods
+	series, err := forecasting.ParsePoints(input.Points)
+	if err != nil {
+		respondCSVError(c, err)
+		return forecastInput{}, false
+	}
+	csvData, err := forecasting.SeriesToCSV(series)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build CSV"})
+		return forecastInput{}, false
 	}
-	defer resp.Body.Close()
+	return forecastInput{
+		Source:    forecastSourceJSON,
+		InputHash: hashInput(csvData),
+		StoreID:   auth.CurrentStoreID(c),
+		Model:     input.Model,
+		Periods:   input.Periods,
+		Series:    series,
+	}, true
+}
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Prediction failed",
-			"details": string(bodyBytes),
-			"status":  resp.StatusCode,
-		})
+// FromTransactions godoc
+// @Summary Forecast from stored transactions
+// @Description Aggregate daily sold quantity from the transactions table (optional product_id & date range, Asia/Jakarta), send it to the forecaster as date/projected_quantity and return the forecast. No CSV upload needed.
+// @Tags forecast
+// @Accept json
+// @Produce json
+// @Param input body TransactionForecastInput true "Forecast input (product_id, start_date, end_date, model optional; periods default 30, max 365)"
+// @Success 200 {object} controllers.ForecastResponse
+// @Failure 400 {object} map[string]string "Invalid input or not enough transaction history"
+// @Failure 500 {object} map[string]string "Server error (e.g., ML service failed)"
+// @Param no_cache query bool false "Skip cached result and forecast again (result is re-cached)"
+// @Header 200 {string} X-Cache "HIT, MISS or BYPASS"
+// @Router /api/v1/forecast/transactions [post]
+func (ctrl *UploadForecastController) FromTransactions(c *gin.Context) {
+	in, ok := ctrl.transactionsInput(c)
+	if !ok {
 		return
 	}
-
-	// Parse response
-	bodyBytes, err := io.ReadAll(resp.Body)
+	forecastResp, err := ctrl.cachedForecast(c, in)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read response"})
+		respondForecastError(c, err)
 		return
 	}
+	c.JSON(http.StatusOK, forecastResp)
+}
+
+// transactionsInput baca body TransactionForecastInput & agregasi series dari tabel transactions.
+// Kalau invalid, response error sudah ditulis & return false.
+func (ctrl *UploadForecastController) transactionsInput(c *gin.Context) (forecastInput, bool) {
+	var input TransactionForecastInput
+	// ShouldBindBodyWith biar body bisa dibaca ulang (CreateJob cek dulu apakah ada 'points')
+	if err := c.ShouldBindBodyWith(&input, binding.JSON); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid input: %v", err)})
+		return forecastInput{}, false
+	}
+	periods, err := resolvePeriods(input.Periods)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return forecastInput{}, false
+	}
+	input.Periods = periods
+	dateRange, err := parseDateRangeValues(input.StartDate, input.EndDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return forecastInput{}, false
+	}
+	var productIDs []uint
+	if input.ProductID != nil {
+		productIDs = []uint{*input.ProductID}
+	}
 
-	var forecastResp ForecastResponse
-	err = json.Unmarshal(bodyBytes, &forecastResp)
+	// Tanpa product_id: total semua produk di toko aktif (kantor pusat tanpa X-Store-ID: semua toko)
+	series, err := dailyQuantitySeries(ctrl.DB.Scopes(storeScope(c, "transactions.store_id")), productIDs, dateRange)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":     "Failed to parse prediction",
-			"details":   err.Error(),
-			"raw":       string(bodyBytes),
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		return forecastInput{}, false
+	}
+	if len(series) < minHistoryDays {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Not enough transaction history (need at least %d days, got %d)", minHistoryDays, len(series)),
 		})
-		return
+		return forecastInput{}, false
 	}
 
-	c.JSON(http.StatusOK, forecastResp)
-}
\ No newline at end of file
+	in, err := transactionSeriesInput(input.ProductID, series, input.Model, input.Periods)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build CSV"})
+		return forecastInput{}, false
+	}
+	in.StoreID = auth.CurrentStoreID(c)
+	return in, true
+}
+
+// transactionSeriesInput forecastInput dari series hasil agregasi transactions (hash = CSV ternormalisasi)
+func transactionSeriesInput(productID *uint, series []forecasting.Point, model string, periods int) (forecastInput, error) {
+	csvData, err := forecasting.SeriesToCSV(series)
+	if err != nil {
+		return forecastInput{}, err
+	}
+	return forecastInput{
+		Source:    forecastSourceTransactions,
+		InputHash: hashInput(csvData),
+		ProductID: productID,
+		Model:     model,
+		Periods:   periods,
+		Series:    series,
+	}, nil
+}
@@ -0,0 +1,281 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"backend-penjualan/auth"
+	"backend-penjualan/forecasting"
+	"backend-penjualan/jobs"
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const defaultHoldoutDays = 14
+
+// BacktestInput body buat backtest forecast terhadap histori transactions
+type BacktestInput struct {
+	ProductIDs  []uint `json:"product_ids"`  // Kosong = semua produk yang punya transaksi di rentang tanggal
+	HoldoutDays int    `json:"holdout_days"` // Jumlah hari terakhir yang disembunyikan lalu diprediksi (default 14, max 365)
+	StartDate   string `json:"start_date"`   // YYYY-MM-DD, optional
+	EndDate     string `json:"end_date"`     // YYYY-MM-DD, optional
+	Model       string `json:"model"`        // auto (default), prophet, holt_winters, moving_average
+}
+
+// BacktestProductResult akurasi forecast satu produk
+type BacktestProductResult struct {
+	ProductID      uint                  `json:"product_id"`
+	NamaProduk     string                `json:"nama_produk,omitempty"`
+	Model          string                `json:"model,omitempty"` // Model yang benar-benar dipakai
+	FallbackReason string                `json:"fallback_reason,omitempty"`
+	TrainDays      int                   `json:"train_days"`
+	Accuracy       *forecasting.Accuracy `json:"accuracy,omitempty"`
+	Error          string                `json:"error,omitempty"` // Kenapa produk ini gak bisa di-backtest
+}
+
+// BacktestResponse hasil backtest semua produk
+type BacktestResponse struct {
+	Model       string                  `json:"model"` // Model yang diminta
+	HoldoutDays int                     `json:"holdout_days"`
+	Results     []BacktestProductResult `json:"results"`
+}
+
+// Backtest godoc
+// @Summary Backtest forecast accuracy per product
+// @Description Hold out the last N days of each product's daily transaction history, forecast them with the selected model (default auto: ML service with native fallback) from the remaining days, and report MAPE (%), MAE and RMSE against the actual sales. Days with zero actual sales are skipped for MAPE.
+// @Tags forecast
+// @Accept json
+// @Produce json
+// @Param input body BacktestInput false "Products, holdout days, date range & model"
+// @Success 200 {object} controllers.BacktestResponse
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 500 {object} map[string]string "Query failed"
+// @Router /api/v1/forecast/backtest [post]
+func (ctrl *UploadForecastController) Backtest(c *gin.Context) {
+	var input BacktestInput
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid input: %v", err)})
+		return
+	}
+	if input.HoldoutDays < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "holdout_days must be positive"})
+		return
+	}
+	if input.HoldoutDays > forecasting.MaxPeriods {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("holdout_days max %d", forecasting.MaxPeriods)})
+		return
+	}
+	if input.HoldoutDays == 0 {
+		input.HoldoutDays = defaultHoldoutDays
+	}
+	if input.Model == "" {
+		input.Model = forecasting.ModelAuto
+	}
+	forecaster, err := forecasting.New(input.Model, ctrl.ML)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	dateRange, err := parseDateRangeValues(input.StartDate, input.EndDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	productIDs := input.ProductIDs
+	if len(productIDs) == 0 {
+		query := ctrl.DB.Model(&models.Transaction{}).Scopes(storeScope(c, "store_id"))
+		if dateRange.Start != nil {
+			query = query.Where("created_at >= ?", *dateRange.Start)
+		}
+		if dateRange.End != nil {
+			query = query.Where("created_at < ?", *dateRange.End)
+		}
+		if err := query.Distinct().Order("product_id").Pluck("product_id", &productIDs).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+			return
+		}
+	}
+	var products []models.Product
+	if err := ctrl.DB.Scopes(storeScope(c, "store_id")).Where("id IN ?", productIDs).Find(&products).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		return
+	}
+	names := make(map[uint]string, len(products))
+	for _, p := range products {
+		names[p.ID] = p.Nama
+	}
+
+	resp := BacktestResponse{Model: input.Model, HoldoutDays: input.HoldoutDays, Results: []BacktestProductResult{}}
+	for _, productID := range productIDs {
+		result := BacktestProductResult{ProductID: productID, NamaProduk: names[productID]}
+		if _, ok := names[productID]; !ok {
+			result.Error = "Product not found"
+		} else if err := ctrl.backtestProduct(c.Request.Context(), forecaster, productID, dateRange, input.HoldoutDays, &result); err != nil {
+			result.Error = err.Error()
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// backtestProduct forecast holdoutDays terakhir dari sisa histori & bandingkan dengan actual
+func (ctrl *UploadForecastController) backtestProduct(ctx context.Context, forecaster forecasting.Forecaster, productID uint, r DateRange, holdoutDays int, result *BacktestProductResult) error {
+	series, err := dailyQuantitySeries(ctrl.DB, []uint{productID}, r)
+	if err != nil {
+		return err
+	}
+	if len(series) < holdoutDays+minHistoryDays {
+		return fmt.Errorf("Not enough transaction history (need at least %d days, got %d)", holdoutDays+minHistoryDays, len(series))
+	}
+	train, holdout := series[:len(series)-holdoutDays], series[len(series)-holdoutDays:]
+	result.TrainDays = len(train)
+
+	holidays, err := loadHolidays(ctrl.DB.WithContext(ctx), train, holdoutDays)
+	if err != nil {
+		return err
+	}
+	forecast, err := forecaster.Forecast(ctx, forecasting.Request{Series: train, Periods: holdoutDays, Holidays: holidays})
+	if err != nil {
+		return err
+	}
+	result.Model = forecast.Model
+	result.FallbackReason = forecast.FallbackReason
+
+	predictedByDate := make(map[string]float64, len(forecast.Forecast))
+	for _, row := range forecast.Forecast {
+		if row.Yhat != nil {
+			predictedByDate[row.Date] = *row.Yhat
+		}
+	}
+	var actual, predicted []float64
+	for _, p := range holdout {
+		if yhat, ok := predictedByDate[p.Date.Format(dateLayout)]; ok {
+			actual = append(actual, p.Value)
+			predicted = append(predicted, yhat)
+		}
+	}
+	accuracy, err := forecasting.Evaluate(actual, predicted)
+	if err != nil {
+		return err
+	}
+	result.Accuracy = &accuracy
+	return nil
+}
+
+// evaluateRuns bandingkan forecast run (dari transactions) dengan penjualan aktual yang sudah masuk,
+// lalu simpan MAPE/MAE/RMSE di forecast_runs. Run yang horizon-nya belum lewat semua dievaluasi ulang di jadwal berikutnya.
+// storeID != nil cuma evaluasi run toko itu; actual selalu diambil dari toko pemilik run.
+func (ctrl *UploadForecastController) evaluateRuns(ctx context.Context, storeID *uint) (int, error) {
+	now := time.Now().In(AppLocation)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, AppLocation)
+
+	query := ctrl.DB.WithContext(ctx)
+	if storeID != nil {
+		query = query.Where("store_id = ?", *storeID)
+	}
+	var runs []models.ForecastRun
+	err := query.
+		Preload("Points", func(db *gorm.DB) *gorm.DB {
+			return db.Where("kind = ? AND date < ?", models.ForecastPointForecast, today.Format(dateLayout)).Order("date")
+		}).
+		Where("source = ? AND status = ?", forecastSourceTransactions, models.ForecastStatusSuccess).
+		Where("evaluated_at IS NULL OR evaluated_points < periods").
+		Find(&runs).Error
+	if err != nil {
+		return 0, err
+	}
+
+	evaluated := 0
+	for _, run := range runs {
+		if len(run.Points) == 0 {
+			continue // Belum ada hari forecast yang lewat
+		}
+		first, _ := time.ParseInLocation(dateLayout, run.Points[0].Date.Format(dateLayout), AppLocation)
+		var productIDs []uint
+		if run.ProductID != nil {
+			productIDs = []uint{*run.ProductID}
+		}
+		actualDB := ctrl.DB.WithContext(ctx)
+		if run.StoreID != nil {
+			actualDB = actualDB.Where("transactions.store_id = ?", *run.StoreID)
+		}
+		series, err := dailyQuantitySeries(actualDB, productIDs, DateRange{Start: &first, End: &today})
+		if err != nil {
+			return evaluated, err
+		}
+		actualByDate := make(map[string]float64, len(series))
+		for _, p := range series {
+			actualByDate[p.Date.Format(dateLayout)] = p.Value
+		}
+
+		var actual, predicted []float64
+		for _, p := range run.Points {
+			if p.Yhat == nil {
+				continue
+			}
+			actual = append(actual, actualByDate[p.Date.Format(dateLayout)]) // Gak ada transaksi = 0 terjual
+			predicted = append(predicted, *p.Yhat)
+		}
+		accuracy, err := forecasting.Evaluate(actual, predicted)
+		if err != nil {
+			continue
+		}
+		if err := ctrl.DB.WithContext(ctx).Model(&models.ForecastRun{}).Where("id = ?", run.ID).Updates(map[string]interface{}{
+			"mape":             accuracy.MAPE,
+			"mae":              accuracy.MAE,
+			"rmse":             accuracy.RMSE,
+			"evaluated_points": accuracy.Points,
+			"evaluated_at":     time.Now(),
+		}).Error; err != nil {
+			return evaluated, err
+		}
+		evaluated++
+	}
+	return evaluated, nil
+}
+
+// EvaluateRuns godoc
+// @Summary Evaluate stored forecasts against actual sales
+// @Description Compare stored forecast runs (source transactions) with the actual sales that arrived after they were made and store MAPE, MAE and RMSE on each run (store users only evaluate their own store's runs). Also runs automatically every FORECAST_EVAL_INTERVAL (default 6h).
+// @Tags forecast
+// @Produce json
+// @Success 200 {object} map[string]int "Number of runs evaluated"
+// @Failure 500 {object} map[string]string "Evaluation failed"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin or manager role)"
+// @Router /api/v1/forecast/runs/evaluate [post]
+func (ctrl *UploadForecastController) EvaluateRuns(c *gin.Context) {
+	evaluated, err := ctrl.evaluateRuns(c.Request.Context(), auth.CurrentStoreID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Evaluation failed: %v", err.Error())})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"evaluated": evaluated})
+}
+
+// Wait tunggu worker forecast job selesai mengembalikan job yang lagi jalan ke antrian (dipanggil waktu shutdown)
+func (ctrl *UploadForecastController) Wait(ctx context.Context) error {
+	return ctrl.Jobs.Wait(ctx)
+}
+
+// StartBackground jalankan worker pool forecast job, evaluasi akurasi & purge cache terjadwal
+func (ctrl *UploadForecastController) StartBackground(ctx context.Context) {
+	ctrl.Jobs.Start(ctx)
+	jobs.Every(ctx, "forecast accuracy evaluation", ctrl.EvalInterval, func(ctx context.Context) error {
+		evaluated, err := ctrl.evaluateRuns(ctx, nil)
+		if evaluated > 0 {
+			log.Printf("Evaluated accuracy of %d forecast run(s)", evaluated)
+		}
+		return err
+	})
+	if ctrl.cacheStore != nil {
+		jobs.Every(ctx, "forecast cache purge", time.Hour, ctrl.purgeForecastCache)
+	}
+}
@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestBacktestRejectsInvalidHoldoutDays(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"negative", `{"holdout_days":-1}`, "holdout_days must be positive"},
+		{"over max periods", `{"holdout_days":366}`, "holdout_days max 365"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeDB(t, func(string) fakeResult { return fakeResult{columns: []string{"id"}} })
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/forecast/backtest", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			(&UploadForecastController{DB: fake.db}).Backtest(c)
+
+			if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), tt.wantErr) {
+				t.Fatalf("status = %d body = %s, want 400 %q", w.Code, w.Body, tt.wantErr)
+			}
+			if statements := fake.Statements(); len(statements) > 0 {
+				t.Errorf("statements = %q, want none before validation passes", statements)
+			}
+		})
+	}
+}
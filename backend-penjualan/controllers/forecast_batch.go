@@ -0,0 +1,200 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+
+	"backend-penjualan/forecasting"
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxBatchConcurrency = 16
+
+// BatchForecastInput body buat forecast banyak produk sekaligus dari tabel transactions
+type BatchForecastInput struct {
+	ProductIDs  []uint `json:"product_ids"` // Kosong = semua produk aktif
+	StartDate   string `json:"start_date"`  // YYYY-MM-DD, optional
+	EndDate     string `json:"end_date"`    // YYYY-MM-DD, optional
+	Periods     int    `json:"periods"`     // Default 30, max 365
+	Model       string `json:"model"`       // auto (default), prophet, holt_winters, moving_average
+	Concurrency int    `json:"concurrency"` // Forecast paralel maksimal (default FORECAST_BATCH_CONCURRENCY atau 4, max 16)
+}
+
+// BatchForecastResult hasil forecast satu produk
+type BatchForecastResult struct {
+	ProductID  uint   `json:"product_id"`
+	NamaProduk string `json:"nama_produk"`
+	Cache      string `json:"cache,omitempty"` // HIT / MISS / BYPASS
+	ForecastResponse
+}
+
+// BatchForecastSkipped produk yang gak di-forecast (histori kurang / gak ketemu) atau gagal
+type BatchForecastSkipped struct {
+	ProductID   uint   `json:"product_id"`
+	NamaProduk  string `json:"nama_produk,omitempty"`
+	HistoryDays int    `json:"history_days"`
+	Reason      string `json:"reason"`
+}
+
+// BatchForecastResponse hasil batch: sukses, di-skip, & gagal
+type BatchForecastResponse struct {
+	Results []BatchForecastResult  `json:"results"`
+	Skipped []BatchForecastSkipped `json:"skipped"`
+	Failed  []BatchForecastSkipped `json:"failed"`
+}
+
+// batchItem hasil satu produk (diisi goroutine, urutan sama dengan daftar produk)
+type batchItem struct {
+	result  *BatchForecastResult
+	skipped *BatchForecastSkipped
+	failed  *BatchForecastSkipped
+}
+
+// Batch godoc
+// @Summary Forecast many products at once
+// @Description Forecast every active product (or the given product_ids) from its daily transaction history, running up to `concurrency` forecasts in parallel against the ML service (with native fallback for model auto). Products with less than 7 days of history (or unknown IDs) are listed in skipped, forecast errors in failed. Each successful forecast is stored as a run and cached like /forecast/transactions.
+// @Tags forecast
+// @Accept json
+// @Produce json
+// @Param input body BatchForecastInput false "Products, date range, periods, model & concurrency"
+// @Param no_cache query bool false "Skip cached results and forecast again"
+// @Success 200 {object} controllers.BatchForecastResponse
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 500 {object} map[string]string "Query failed"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin or manager role)"
+// @Router /api/v1/forecast/batch [post]
+func (ctrl *UploadForecastController) Batch(c *gin.Context) {
+	var input BatchForecastInput
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid input: %v", err)})
+		return
+	}
+	periods, err := resolvePeriods(input.Periods)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	input.Periods = periods
+	if input.Concurrency < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Concurrency must be positive"})
+		return
+	}
+	if input.Concurrency == 0 {
+		input.Concurrency = 4
+		if n, err := strconv.Atoi(os.Getenv("FORECAST_BATCH_CONCURRENCY")); err == nil && n > 0 {
+			input.Concurrency = n
+		}
+	}
+	if input.Concurrency > maxBatchConcurrency {
+		input.Concurrency = maxBatchConcurrency
+	}
+	if _, err := forecasting.New(input.Model, ctrl.ML); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	dateRange, err := parseDateRangeValues(input.StartDate, input.EndDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var products []models.Product
+	query := ctrl.DB.Scopes(storeScope(c, "store_id")).Order("id") // Produk di trash otomatis gak ikut (soft delete)
+	if len(input.ProductIDs) > 0 {
+		query = query.Where("id IN ?", input.ProductIDs)
+	}
+	if err := query.Find(&products).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		return
+	}
+
+	resp := BatchForecastResponse{
+		Results: []BatchForecastResult{},
+		Skipped: []BatchForecastSkipped{},
+		Failed:  []BatchForecastSkipped{},
+	}
+	found := make(map[uint]bool, len(products))
+	for _, p := range products {
+		found[p.ID] = true
+	}
+	for _, id := range input.ProductIDs {
+		if !found[id] {
+			resp.Skipped = append(resp.Skipped, BatchForecastSkipped{ProductID: id, Reason: "Product not found"})
+			found[id] = true // Jangan dobel kalau ID diulang
+		}
+	}
+
+	items := make([]batchItem, len(products))
+	bypass := bypassCache(c)
+	sem := make(chan struct{}, input.Concurrency)
+	var wg sync.WaitGroup
+	for i, product := range products {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, product models.Product) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			// Goroutine di luar Recovery gin: panic satu produk cukup jadi failed, jangan matikan server
+			defer func() {
+				if r := recover(); r != nil {
+					items[i] = batchItem{failed: &BatchForecastSkipped{ProductID: product.ID, NamaProduk: product.Nama, Reason: fmt.Sprintf("Forecast panicked: %v", r)}}
+				}
+			}()
+			items[i] = ctrl.batchForecastProduct(c.Request.Context(), product, dateRange, input, bypass)
+		}(i, product)
+	}
+	wg.Wait()
+
+	for _, item := range items {
+		switch {
+		case item.result != nil:
+			resp.Results = append(resp.Results, *item.result)
+		case item.skipped != nil:
+			resp.Skipped = append(resp.Skipped, *item.skipped)
+		case item.failed != nil:
+			resp.Failed = append(resp.Failed, *item.failed)
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// batchForecastProduct forecast satu produk (dipanggil paralel)
+func (ctrl *UploadForecastController) batchForecastProduct(ctx context.Context, product models.Product, r DateRange, input BatchForecastInput, bypass bool) batchItem {
+	productID := product.ID
+	series, err := dailyQuantitySeries(ctrl.DB.WithContext(ctx), []uint{productID}, r)
+	if err != nil {
+		return batchItem{failed: &BatchForecastSkipped{ProductID: productID, NamaProduk: product.Nama, Reason: err.Error()}}
+	}
+	if len(series) < minHistoryDays {
+		return batchItem{skipped: &BatchForecastSkipped{
+			ProductID:   productID,
+			NamaProduk:  product.Nama,
+			HistoryDays: len(series),
+			Reason:      fmt.Sprintf("Not enough transaction history (need at least %d days)", minHistoryDays),
+		}}
+	}
+	in, err := transactionSeriesInput(&productID, series, input.Model, input.Periods)
+	if err != nil {
+		return batchItem{failed: &BatchForecastSkipped{ProductID: productID, NamaProduk: product.Nama, HistoryDays: len(series), Reason: err.Error()}}
+	}
+	storeID := product.StoreID
+	in.StoreID = &storeID
+	resp, cacheStatus, err := ctrl.forecastWithCache(ctx, in, bypass)
+	if err != nil {
+		return batchItem{failed: &BatchForecastSkipped{ProductID: productID, NamaProduk: product.Nama, HistoryDays: len(series), Reason: err.Error()}}
+	}
+	return batchItem{result: &BatchForecastResult{
+		ProductID:        productID,
+		NamaProduk:       product.Nama,
+		Cache:            cacheStatus,
+		ForecastResponse: *resp,
+	}}
+}
@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend-penjualan/cache"
+	"backend-penjualan/forecasting"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Nilai header X-Cache
+const (
+	cacheHit    = "HIT"
+	cacheMiss   = "MISS"
+	cacheBypass = "BYPASS"
+)
+
+// newForecastCache cache hasil forecast dari env: FORECAST_CACHE_TTL (default 6h, 0 = nonaktif),
+// FORECAST_CACHE_SIZE (default 500 entry), FORECAST_CACHE_PERSIST=true simpan juga ke Postgres
+func newForecastCache(db *gorm.DB) (*cache.Cache, *cache.PostgresStore) {
+	ttl := 6 * time.Hour
+	if raw := os.Getenv("FORECAST_CACHE_TTL"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			log.Printf("Warning: invalid FORECAST_CACHE_TTL %q, using %s", raw, ttl)
+		} else {
+			ttl = d
+		}
+	}
+	if ttl <= 0 {
+		return nil, nil
+	}
+	size := 500
+	if n, err := strconv.Atoi(os.Getenv("FORECAST_CACHE_SIZE")); err == nil && n > 0 {
+		size = n
+	}
+	var store *cache.PostgresStore
+	if os.Getenv("FORECAST_CACHE_PERSIST") == "true" {
+		store = cache.NewPostgresStore(db)
+		return cache.New(ttl, size, store), store
+	}
+	return cache.New(ttl, size, nil), nil
+}
+
+// forecastCacheKey hash input + parameter (termasuk holiday) yang mempengaruhi hasil
+func forecastCacheKey(in forecastInput) string {
+	model := in.Model
+	if model == "" {
+		model = forecasting.ModelAuto
+	}
+	productID := ""
+	if in.ProductID != nil {
+		productID = strconv.FormatUint(uint64(*in.ProductID), 10)
+	}
+	// Toko ikut key: cache hit mengembalikan run_id, jadi jangan sampai run toko lain yang terbawa
+	storeID := ""
+	if in.StoreID != nil {
+		storeID = strconv.FormatUint(uint64(*in.StoreID), 10)
+	}
+	parts := []string{in.Source, in.InputHash, productID, storeID, model, strconv.Itoa(in.Periods)}
+	// Holiday ikut key biar perubahan kalender langsung dipakai
+	for _, h := range in.Holidays {
+		parts = append(parts, fmt.Sprintf("%s@%s[%d,%d]", h.Name, h.Date.Format(dateLayout), h.LowerWindow, h.UpperWindow))
+	}
+	return hashInput([]byte(strings.Join(parts, "|")))
+}
+
+// bypassCache ?no_cache=true (atau header Cache-Control: no-cache) paksa forecast ulang
+func bypassCache(c *gin.Context) bool {
+	if v, err := strconv.ParseBool(c.Query("no_cache")); err == nil && v {
+		return true
+	}
+	return strings.Contains(strings.ToLower(c.GetHeader("Cache-Control")), "no-cache")
+}
+
+// cachedForecast ambil hasil dari cache kalau ada, kalau belum jalankan forecast & simpan.
+// Status cache dikirim lewat header X-Cache.
+func (ctrl *UploadForecastController) cachedForecast(c *gin.Context, in forecastInput) (*ForecastResponse, error) {
+	resp, status, err := ctrl.forecastWithCache(c.Request.Context(), in, bypassCache(c))
+	if status != "" {
+		c.Header("X-Cache", status)
+	}
+	return resp, err
+}
+
+// forecastWithCache versi tanpa gin.Context (dipakai juga batch). Status kosong kalau cache nonaktif.
+// Hasil fallback (ML down) gak di-cache.
+func (ctrl *UploadForecastController) forecastWithCache(ctx context.Context, in forecastInput, bypass bool) (*ForecastResponse, string, error) {
+	in = ctrl.withHolidays(ctx, in)
+	if ctrl.Cache == nil {
+		resp, err := ctrl.runForecast(ctx, in)
+		return resp, "", err
+	}
+	key := forecastCacheKey(in)
+	status := cacheMiss
+	if bypass {
+		status = cacheBypass
+	} else if data, ok := ctrl.Cache.Get(key); ok {
+		var cached ForecastResponse
+		if err := json.Unmarshal(data, &cached); err == nil {
+			return &cached, cacheHit, nil
+		}
+	}
+
+	resp, err := ctrl.runForecast(ctx, in)
+	if err == nil && resp.FallbackReason == "" {
+		if data, marshalErr := json.Marshal(resp); marshalErr == nil {
+			ctrl.Cache.Set(key, data)
+		}
+	}
+	return resp, status, err
+}
+
+// purgeForecastCache hapus cache Postgres yang sudah expired (dijalankan terjadwal)
+func (ctrl *UploadForecastController) purgeForecastCache(ctx context.Context) error {
+	if ctrl.cacheStore == nil {
+		return nil
+	}
+	purged, err := ctrl.cacheStore.Purge()
+	if err != nil {
+		return fmt.Errorf("purge forecast cache: %w", err)
+	}
+	if purged > 0 {
+		log.Printf("Purged %d expired forecast cache entries", purged)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"backend-penjualan/forecasting"
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"gorm.io/gorm"
+)
+
+// processJob dipanggil worker pool: parse series dari job, jalankan forecast & simpan run-nya
+func (ctrl *UploadForecastController) processJob(ctx context.Context, job *models.ForecastJob) (*uint, error) {
+	series, err := forecasting.ParseCSV(strings.NewReader(job.Input))
+	if err != nil {
+		return nil, err
+	}
+	in := forecastInput{
+		Source:    job.Source,
+		Filename:  job.InputFilename,
+		InputHash: job.InputHash,
+		ProductID: job.ProductID,
+		StoreID:   job.StoreID,
+		Model:     job.Model,
+		Periods:   job.Periods,
+		Series:    series,
+	}
+	in = ctrl.withHolidays(ctx, in)
+	resp, err := ctrl.forecast(ctx, in)
+	if errors.Is(err, forecasting.ErrUnknownModel) || errors.Is(err, forecasting.ErrInsufficientData) {
+		return nil, err
+	}
+	// Run gagal juga disimpan, biar job failed tetap bisa ditelusuri lewat run_id
+	run := ctrl.saveForecastRun(in, resp, err)
+	if run == nil {
+		return nil, err
+	}
+	return &run.ID, err
+}
+
+// CreateJob godoc
+// @Summary Queue forecast job (async)
+// @Description Queue a forecast without waiting for the ML service. Accepts the same multipart CSV/XLSX upload as /forecast/upload (csvFile, format, sheet, date_column, value_column, periods, model), a JSON body with 'points' like /forecast/upload, or the same JSON body as /forecast/transactions. Returns 202 with the job; poll GET /forecast/jobs/{id} until status is done or failed.
+// @Tags forecast
+// @Accept multipart/form-data,json
+// @Produce json
+// @Param csvFile formData file false "CSV or XLSX file with historical data (multipart)"
+// @Param format formData string false "File format (default from extension, multipart)" Enums(csv, xlsx)
+// @Param sheet formData string false "XLSX sheet name (default first sheet, multipart)"
+// @Param date_column formData string false "Date column: header name or column letter (multipart)"
+// @Param value_column formData string false "Value column: header name or column letter (multipart)"
+// @Param periods formData int false "Number of days to forecast (default 30, max 365, multipart)"
+// @Param model formData string false "Forecast model (default auto, multipart)" Enums(auto, prophet, holt_winters, moving_average)
+// @Param input body TransactionForecastInput false "Forecast from transactions (JSON); send PointsForecastInput instead to forecast from points"
+// @Success 202 {object} models.ForecastJob "Queued job"
+// @Failure 400 {object} forecasting.CSVError "Invalid file/points (row-by-row report) or input"
+// @Failure 413 {object} map[string]string "File too large (max 5 MB)"
+// @Failure 500 {object} map[string]string "Failed to queue job"
+// @Router /api/v1/forecast/jobs [post]
+func (ctrl *UploadForecastController) CreateJob(c *gin.Context) {
+	var (
+		in forecastInput
+		ok bool
+	)
+	var body struct {
+		Points json.RawMessage `json:"points"`
+	}
+	switch {
+	case strings.HasPrefix(c.ContentType(), "multipart/"):
+		in, ok = ctrl.uploadInput(c)
+	case c.ShouldBindBodyWith(&body, binding.JSON) == nil && len(body.Points) > 0:
+		in, ok = ctrl.pointsInput(c)
+	default:
+		in, ok = ctrl.transactionsInput(c)
+	}
+	if !ok {
+		return
+	}
+	// Validasi model di depan, jangan sampai baru ketahuan di worker
+	if _, err := forecasting.New(in.Model, ctrl.ML); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	csvData, err := forecasting.SeriesToCSV(in.Series)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build CSV"})
+		return
+	}
+
+	job := models.ForecastJob{
+		Source:        in.Source,
+		InputHash:     in.InputHash,
+		InputFilename: in.Filename,
+		ProductID:     in.ProductID,
+		StoreID:       in.StoreID,
+		Model:         in.Model,
+		Periods:       in.Periods,
+		Input:         string(csvData),
+	}
+	if job.Model == "" {
+		job.Model = forecasting.ModelAuto
+	}
+	if err := ctrl.Jobs.Enqueue(&job); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to queue job: %v", err.Error())})
+		return
+	}
+	c.Header("Location", fmt.Sprintf("/api/v1/forecast/jobs/%d", job.ID))
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetJob godoc
+// @Summary Get forecast job status
+// @Description Poll a forecast job. Status is queued, running, done or failed; when done (or failed after the forecast ran) the stored run with its points is included.
+// @Tags forecast
+// @Accept json
+// @Produce json
+// @Param id path int true "Forecast job ID"
+// @Success 200 {object} models.ForecastJob "Forecast job (with run when finished)"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 404 {object} map[string]string "Forecast job not found"
+// @Failure 500 {object} map[string]string "Query failed"
+// @Router /api/v1/forecast/jobs/{id} [get]
+func (ctrl *UploadForecastController) GetJob(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+	var job models.ForecastJob
+	err = ctrl.DB.Scopes(storeScope(c, "store_id")).Preload("Run.Points", func(db *gorm.DB) *gorm.DB {
+		return db.Order("kind DESC, date")
+	}).First(&job, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Forecast job not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
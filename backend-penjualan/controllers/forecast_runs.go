@@ -0,0 +1,192 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"backend-penjualan/forecasting"
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Sumber input forecast run
+const (
+	forecastSourceUpload       = "upload"
+	forecastSourceJSON         = "json"
+	forecastSourceTransactions = "transactions"
+)
+
+// forecastRunSortFields whitelist field yang boleh dipakai di ?sort=
+var forecastRunSortFields = map[string]string{
+	"id":         "id",
+	"created_at": "created_at",
+	"periods":    "periods",
+	"model":      "model",
+	"status":     "status",
+	"mape":       "mape",
+	"mae":        "mae",
+	"rmse":       "rmse",
+}
+
+// hashInput SHA-256 hex dari isi file / series input
+func hashInput(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// saveForecastRun simpan run + titik hasilnya. Gagal simpan cuma di-log, response forecast tetap jalan.
+func (ctrl *UploadForecastController) saveForecastRun(in forecastInput, resp *ForecastResponse, runErr error) *models.ForecastRun {
+	run := models.ForecastRun{
+		Source:         in.Source,
+		InputHash:      in.InputHash,
+		InputFilename:  in.Filename,
+		ProductID:      in.ProductID,
+		StoreID:        in.StoreID,
+		Periods:        in.Periods,
+		ModelRequested: in.Model,
+		Status:         models.ForecastStatusSuccess,
+	}
+	if run.ModelRequested == "" {
+		run.ModelRequested = forecasting.ModelAuto
+	}
+	if runErr != nil {
+		run.Status = models.ForecastStatusFailed
+		run.Error = runErr.Error()
+	} else {
+		run.Model = resp.Model
+		run.FallbackReason = resp.FallbackReason
+		run.Points = append(resultToPoints(models.ForecastPointHistorical, resp.Historical),
+			resultToPoints(models.ForecastPointForecast, resp.Forecast)...)
+	}
+	if err := ctrl.DB.Create(&run).Error; err != nil {
+		log.Printf("Warning: failed to save forecast run: %v", err)
+		return nil
+	}
+	return &run
+}
+
+// resultToPoints konversi titik hasil forecast (ML atau native) ke row forecast_points
+func resultToPoints(kind string, rows []forecasting.ResultPoint) []models.ForecastPoint {
+	points := make([]models.ForecastPoint, 0, len(rows))
+	for _, row := range rows {
+		date, err := row.Time()
+		if err != nil {
+			continue // Sudah divalidasi forecaster, jaga-jaga saja
+		}
+		points = append(points, models.ForecastPoint{
+			Kind:      kind,
+			Date:      date,
+			Actual:    row.Actual,
+			Yhat:      row.Yhat,
+			YhatLower: row.YhatLower,
+			YhatUpper: row.YhatUpper,
+			Trend:     row.Trend,
+			Weekly:    row.Weekly,
+			Yearly:    row.Yearly,
+			Holidays:  row.Holidays,
+		})
+	}
+	return points
+}
+
+// ListRuns godoc
+// @Summary List forecast runs
+// @Description Retrieve paginated list of stored forecast runs (without points), newest first by default. Store users only see their own store's runs. Total rows returned in X-Total-Count header.
+// @Tags forecast
+// @Accept json
+// @Produce json
+// @Param source query string false "Filter by source" Enums(upload, json, transactions)
+// @Param status query string false "Filter by status" Enums(success, failed)
+// @Param model query string false "Filter by model used (e.g. prophet, holt_winters)"
+// @Param product_id query int false "Filter by product ID"
+// @Param input_hash query string false "Filter by input hash (same file/series)"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (default 50, max 500)"
+// @Param sort query string false "Sort fields, comma separated, prefix - for descending (id, created_at, periods, model, status, mape, mae, rmse)"
+// @Success 200 {array} models.ForecastRun "List of forecast runs"
+// @Header 200 {integer} X-Total-Count "Total runs matching the filters"
+// @Failure 400 {object} map[string]string "Invalid filter, pagination or sort"
+// @Failure 500 {object} map[string]string "Query failed"
+// @Router /api/v1/forecast/runs [get]
+func (ctrl *UploadForecastController) ListRuns(c *gin.Context) {
+	pagination, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sortClause, err := parseSort(c, forecastRunSortFields, "created_at DESC")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := ctrl.DB.Model(&models.ForecastRun{}).Scopes(storeScope(c, "store_id"))
+	for _, field := range []string{"source", "status", "model", "input_hash"} {
+		if v := c.Query(field); v != "" {
+			query = query.Where(field+" = ?", v)
+		}
+	}
+	if productIDStr := c.Query("product_id"); productIDStr != "" {
+		productID, err := strconv.Atoi(productIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product_id format (must be number)"})
+			return
+		}
+		query = query.Where("product_id = ?", productID)
+	}
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		return
+	}
+	var runs []models.ForecastRun
+	if err := query.Order(sortClause).Order("id DESC").Offset(pagination.Offset()).Limit(pagination.Limit).
+		Find(&runs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		return
+	}
+	setPaginationHeaders(c, pagination, total)
+	c.JSON(http.StatusOK, runs)
+}
+
+// GetRun godoc
+// @Summary Get forecast run by ID
+// @Description Retrieve a stored forecast run with all its historical & forecast points
+// @Tags forecast
+// @Accept json
+// @Produce json
+// @Param id path int true "Forecast run ID"
+// @Success 200 {object} models.ForecastRun "Forecast run with points"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 404 {object} map[string]string "Forecast run not found"
+// @Failure 500 {object} map[string]string "Query failed"
+// @Router /api/v1/forecast/runs/{id} [get]
+func (ctrl *UploadForecastController) GetRun(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+	var run models.ForecastRun
+	err = ctrl.DB.Scopes(storeScope(c, "store_id")).Preload("Points", func(db *gorm.DB) *gorm.DB {
+		return db.Order("kind DESC, date")
+	}).First(&run, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Forecast run not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, run)
+}
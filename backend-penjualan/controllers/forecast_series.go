@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	"backend-penjualan/forecasting"
+	"backend-penjualan/models"
+
+	"gorm.io/gorm"
+)
+
+// minHistoryDays minimal jumlah hari histori penjualan biar forecast masuk akal
+const minHistoryDays = 7
+
+// dailyQuantityRow hasil agregasi quantity per hari (Asia/Jakarta)
+type dailyQuantityRow struct {
+	Date     string
+	Quantity float64
+}
+
+// dailyQuantitySeries agregasi quantity harian dari tabel transactions (opsional per product & rentang tanggal).
+// Hari tanpa penjualan diisi 0 biar series-nya kontinu.
+func dailyQuantitySeries(db *gorm.DB, productIDs []uint, r DateRange) ([]forecasting.Point, error) {
+	query := db.Model(&models.Transaction{})
+	if len(productIDs) > 0 {
+		query = query.Where("transactions.product_id IN ?", productIDs)
+	}
+	if r.Start != nil {
+		query = query.Where("transactions.created_at >= ?", *r.Start)
+	}
+	if r.End != nil {
+		query = query.Where("transactions.created_at < ?", *r.End)
+	}
+
+	var rows []dailyQuantityRow
+	dateExpr := fmt.Sprintf("to_char(date_trunc('day', transactions.created_at AT TIME ZONE '%s'), 'YYYY-MM-DD')", appTimezone)
+	if err := query.Select(dateExpr + " AS date, COALESCE(SUM(transactions.quantity), 0) AS quantity").
+		Group("date").Order("date").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	// Isi hari kosong dengan 0, dari start_date (atau penjualan pertama) sampai end_date (atau penjualan terakhir)
+	byDate := make(map[string]float64, len(rows))
+	for _, row := range rows {
+		byDate[row.Date] = row.Quantity
+	}
+	first, _ := time.ParseInLocation(dateLayout, rows[0].Date, AppLocation)
+	last, _ := time.ParseInLocation(dateLayout, rows[len(rows)-1].Date, AppLocation)
+	if r.Start != nil {
+		first = *r.Start
+	}
+	if r.End != nil {
+		last = r.End.AddDate(0, 0, -1)
+		today := time.Now().In(AppLocation)
+		todayDate := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, AppLocation)
+		if last.After(todayDate) {
+			last = todayDate
+		}
+	}
+
+	var series []forecasting.Point
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		series = append(series, forecasting.Point{Date: d, Value: byDate[d.Format(dateLayout)]})
+	}
+	return series, nil
+}
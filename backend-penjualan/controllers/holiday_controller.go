@@ -0,0 +1,326 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend-penjualan/forecasting"
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// HolidayController godoc
+// @Description Holiday controller handles CRUD for the holiday/event calendar used as forecast regressors
+type HolidayController struct {
+	DB *gorm.DB
+}
+
+func NewHolidayController(db *gorm.DB) *HolidayController {
+	return &HolidayController{DB: db}
+}
+
+var errInvalidHolidayInput = errors.New("invalid holiday input")
+
+// maxHolidayWindow batas window efek holiday (hari), juga margin waktu load holiday buat forecast
+const maxHolidayWindow = 30
+
+// holidaySortFields whitelist field yang boleh dipakai di ?sort=
+var holidaySortFields = map[string]string{
+	"id":       "id",
+	"nama":     "nama",
+	"tanggal":  "tanggal",
+	"kategori": "kategori",
+}
+
+// HolidayInput body buat create/update holiday (update: field null gak diubah)
+type HolidayInput struct {
+	Nama        *string `json:"nama"`         // Nama sama di tiap tahun biar efeknya dipelajari (mis. "Hari Raya Idul Fitri")
+	Tanggal     *string `json:"tanggal"`      // YYYY-MM-DD
+	Kategori    *string `json:"kategori"`     // nasional (default) / event
+	LowerWindow *int    `json:"lower_window"` // <= 0, mis. -7 = efek mulai seminggu sebelumnya
+	UpperWindow *int    `json:"upper_window"` // >= 0
+}
+
+// applyHolidayInput validasi & copy field input ke holiday
+func applyHolidayInput(holiday *models.Holiday, input HolidayInput) error {
+	if input.Nama != nil {
+		nama := strings.TrimSpace(*input.Nama)
+		if nama == "" {
+			return fmt.Errorf("%w: nama required", errInvalidHolidayInput)
+		}
+		holiday.Nama = nama
+	}
+	if input.Tanggal != nil {
+		tanggal, err := time.Parse(dateLayout, strings.TrimSpace(*input.Tanggal))
+		if err != nil {
+			return fmt.Errorf("%w: tanggal harus format YYYY-MM-DD", errInvalidHolidayInput)
+		}
+		holiday.Tanggal = tanggal
+	}
+	if input.Kategori != nil {
+		kategori := strings.ToLower(strings.TrimSpace(*input.Kategori))
+		if kategori != models.HolidayKategoriNasional && kategori != models.HolidayKategoriEvent {
+			return fmt.Errorf("%w: kategori harus nasional atau event", errInvalidHolidayInput)
+		}
+		holiday.Kategori = kategori
+	}
+	if input.LowerWindow != nil {
+		if *input.LowerWindow > 0 || *input.LowerWindow < -maxHolidayWindow {
+			return fmt.Errorf("%w: lower_window harus antara -%d dan 0", errInvalidHolidayInput, maxHolidayWindow)
+		}
+		holiday.LowerWindow = *input.LowerWindow
+	}
+	if input.UpperWindow != nil {
+		if *input.UpperWindow < 0 || *input.UpperWindow > maxHolidayWindow {
+			return fmt.Errorf("%w: upper_window harus antara 0 dan %d", errInvalidHolidayInput, maxHolidayWindow)
+		}
+		holiday.UpperWindow = *input.UpperWindow
+	}
+	return nil
+}
+
+// isUniqueViolation cek error duplicate key Postgres (SQLSTATE 23505)
+func isUniqueViolation(err error) bool {
+	return errors.Is(err, gorm.ErrDuplicatedKey) || strings.Contains(err.Error(), "23505")
+}
+
+// loadHolidays holiday yang window-nya bisa mengenai series + horizon forecast
+func loadHolidays(db *gorm.DB, series []forecasting.Point, periods int) ([]forecasting.Holiday, error) {
+	if len(series) == 0 {
+		return nil, nil
+	}
+	from := series[0].Date.AddDate(0, 0, -maxHolidayWindow).Format(dateLayout)
+	to := series[len(series)-1].Date.AddDate(0, 0, periods+maxHolidayWindow).Format(dateLayout)
+
+	var rows []models.Holiday
+	if err := db.Where("tanggal BETWEEN ? AND ?", from, to).Order("tanggal, id").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	holidays := make([]forecasting.Holiday, len(rows))
+	for i, h := range rows {
+		holidays[i] = forecasting.Holiday{Name: h.Nama, Date: h.Tanggal, LowerWindow: h.LowerWindow, UpperWindow: h.UpperWindow}
+	}
+	return holidays, nil
+}
+
+// GetAll godoc
+// @Summary Get all holidays
+// @Description Retrieve paginated holiday/event calendar, optionally filtered by year, category or date range. Total rows returned in X-Total-Count header.
+// @Tags holidays
+// @Accept json
+// @Produce json
+// @Param year query int false "Filter by year"
+// @Param kategori query string false "Filter by category" Enums(nasional, event)
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (default 50, max 500)"
+// @Param sort query string false "Sort fields, comma separated, prefix - for descending (id, nama, tanggal, kategori)"
+// @Success 200 {array} models.Holiday "List of holidays"
+// @Header 200 {integer} X-Total-Count "Total holidays matching the filter"
+// @Failure 400 {object} map[string]string "Invalid filter, pagination or sort"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /holidays [get]
+func (ctrl *HolidayController) GetAll(c *gin.Context) {
+	pagination, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sortClause, err := parseSort(c, holidaySortFields, "tanggal ASC")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	dateRange, err := parseDateRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := ctrl.DB.Model(&models.Holiday{})
+	if yearStr := c.Query("year"); yearStr != "" {
+		year, err := strconv.Atoi(yearStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid year format (must be number)"})
+			return
+		}
+		query = query.Where("EXTRACT(YEAR FROM tanggal) = ?", year)
+	}
+	if kategori := c.Query("kategori"); kategori != "" {
+		query = query.Where("kategori = ?", kategori)
+	}
+	if dateRange.Start != nil {
+		query = query.Where("tanggal >= ?", dateRange.Start.Format(dateLayout))
+	}
+	if dateRange.End != nil {
+		query = query.Where("tanggal < ?", dateRange.End.Format(dateLayout))
+	}
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	var holidays []models.Holiday
+	if err := query.Order(sortClause).Order("id").Offset(pagination.Offset()).Limit(pagination.Limit).
+		Find(&holidays).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	setPaginationHeaders(c, pagination, total)
+	c.JSON(http.StatusOK, holidays)
+}
+
+// GetByID godoc
+// @Summary Get holiday by ID
+// @Description Retrieve a specific holiday/event by ID
+// @Tags holidays
+// @Accept json
+// @Produce json
+// @Param id path int true "Holiday ID"
+// @Success 200 {object} models.Holiday "Holiday details"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 404 {object} map[string]string "Holiday not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /holidays/{id} [get]
+func (ctrl *HolidayController) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var holiday models.Holiday
+	if err := ctrl.DB.First(&holiday, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Holiday not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, holiday)
+}
+
+// Create godoc
+// @Summary Create a holiday or event
+// @Description Add a holiday/event date (nama & tanggal required). Moving holidays (Lebaran, Imlek, Waisak, ...) are added as one row per year with the same nama.
+// @Tags holidays
+// @Accept json
+// @Produce json
+// @Param holiday body HolidayInput true "Holiday data"
+// @Success 201 {object} models.Holiday "Created holiday"
+// @Failure 400 {object} map[string]string "Validation error"
+// @Failure 409 {object} map[string]string "Holiday with same nama & tanggal already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin or manager role)"
+// @Router /holidays [post]
+func (ctrl *HolidayController) Create(c *gin.Context) {
+	var input HolidayInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Nama == nil || input.Tanggal == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Nama & tanggal required"})
+		return
+	}
+	holiday := models.Holiday{Kategori: models.HolidayKategoriNasional}
+	if err := applyHolidayInput(&holiday, input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := ctrl.DB.Create(&holiday).Error; err != nil {
+		if isUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Holiday with same nama & tanggal already exists"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, holiday)
+}
+
+// Update godoc
+// @Summary Update a holiday
+// @Description Update a holiday/event by ID (only provided fields are changed)
+// @Tags holidays
+// @Accept json
+// @Produce json
+// @Param id path int true "Holiday ID"
+// @Param holiday body HolidayInput true "Fields to update"
+// @Success 200 {object} models.Holiday "Updated holiday"
+// @Failure 400 {object} map[string]string "Invalid ID or validation error"
+// @Failure 404 {object} map[string]string "Holiday not found"
+// @Failure 409 {object} map[string]string "Holiday with same nama & tanggal already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin or manager role)"
+// @Router /holidays/{id} [put]
+func (ctrl *HolidayController) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var holiday models.Holiday
+	if err := ctrl.DB.First(&holiday, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Holiday not found"})
+		return
+	}
+	var input HolidayInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := applyHolidayInput(&holiday, input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := ctrl.DB.Save(&holiday).Error; err != nil {
+		if isUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Holiday with same nama & tanggal already exists"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, holiday)
+}
+
+// Delete godoc
+// @Summary Delete a holiday
+// @Description Delete a holiday/event by ID
+// @Tags holidays
+// @Accept json
+// @Produce json
+// @Param id path int true "Holiday ID"
+// @Success 200 {object} map[string]string "Success message"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 404 {object} map[string]string "Holiday not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin or manager role)"
+// @Router /holidays/{id} [delete]
+func (ctrl *HolidayController) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	result := ctrl.DB.Delete(&models.Holiday{}, id)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Holiday not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Holiday deleted"})
+}
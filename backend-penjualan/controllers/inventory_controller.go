@@ -0,0 +1,225 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"backend-penjualan/forecasting"
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultLookbackDays = 90
+	maxLookbackDays     = 730
+	defaultReviewDays   = 7
+)
+
+// InventoryController rekomendasi restock dari forecast demand + stok
+type InventoryController struct {
+	DB *gorm.DB
+	ML forecasting.Forecaster // ML service (boleh nil = native saja)
+}
+
+func NewInventoryController(db *gorm.DB, ml forecasting.Forecaster) *InventoryController {
+	return &InventoryController{DB: db, ML: ml}
+}
+
+// RestockRecommendation reorder point & saran jumlah order satu produk
+type RestockRecommendation struct {
+	ProductID         uint     `json:"product_id"`
+	NamaProduk        string   `json:"nama_produk"`
+	Stok              int      `json:"stok"`
+	LeadTimeDays      int      `json:"lead_time_days"`
+	SafetyStock       int      `json:"safety_stock"`
+	Model             string   `json:"model,omitempty"`           // Model forecast yang dipakai (average kalau histori kurang)
+	FallbackReason    string   `json:"fallback_reason,omitempty"` // Diisi kalau ML down & pakai engine native
+	AvgDailyDemand    float64  `json:"avg_daily_demand"`          // Rata-rata forecast per hari
+	LeadTimeDemand    float64  `json:"lead_time_demand"`          // Forecast demand selama lead time
+	ReorderPoint      int      `json:"reorder_point"`             // Order kalau stok <= angka ini
+	OrderUpTo         int      `json:"order_up_to"`               // Target stok setelah order datang (lead time + review period)
+	NeedsReorder      bool     `json:"needs_reorder"`
+	SuggestedOrderQty int      `json:"suggested_order_qty"`
+	DaysOfCover       *float64 `json:"days_of_cover,omitempty"` // Stok cukup buat berapa hari (null kalau demand 0)
+	Error             string   `json:"error,omitempty"`         // Kenapa forecast produk ini gagal
+}
+
+// averageModel dipakai kalau histori terlalu pendek buat forecast
+const averageModel = "average"
+
+// Recommendations godoc
+// @Summary Restock recommendations
+// @Description Forecast daily demand per product (last lookback_days of transactions) over its lead time plus a review period, then compute reorder point = lead time demand + safety_stock and order-up-to level = (lead time + review) demand + safety_stock. needs_reorder is true when stok <= reorder point; suggested_order_qty = order-up-to - stok. Products with less than 7 days of history use their average daily sales. Products are paginated (X-Total-Count header); reorder candidates come first within a page.
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param product_id query []int false "Filter by product IDs (repeat or comma separated)" collectionFormat(multi)
+// @Param lookback_days query int false "Days of sales history to forecast from (default 90, max 730)"
+// @Param review_days query int false "Days between purchase orders, added on top of lead time for the order-up-to level (default 7); lead_time_days + review_days must not exceed 365"
+// @Param model query string false "Forecast model (default auto)" Enums(auto, prophet, holt_winters, moving_average)
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (default 50, max 500)"
+// @Success 200 {array} controllers.RestockRecommendation
+// @Header 200 {integer} X-Total-Count "Total products matching the filter"
+// @Failure 400 {object} map[string]string "Invalid parameter"
+// @Failure 500 {object} map[string]string "Query failed"
+// @Router /inventory/recommendations [get]
+func (ctrl *InventoryController) Recommendations(c *gin.Context) {
+	pagination, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	productIDs, err := parseIDList(c, "product_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	lookbackDays, err := queryIntInRange(c, "lookback_days", defaultLookbackDays, 1, maxLookbackDays)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	reviewDays, err := queryIntInRange(c, "review_days", defaultReviewDays, 0, forecasting.MaxPeriods)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	forecaster, err := forecasting.New(c.Query("model"), ctrl.ML)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := ctrl.DB.Model(&models.Product{}).Scopes(storeScope(c, "store_id"))
+	if len(productIDs) > 0 {
+		query = query.Where("id IN ?", productIDs)
+	}
+	query = query.Session(&gorm.Session{})
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		return
+	}
+	var products []models.Product
+	if err := query.Order("id").Offset(pagination.Offset()).Limit(pagination.Limit).Find(&products).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		return
+	}
+	// Horizon forecast = lead time + review period, gak boleh lewat batas engine forecast
+	for _, product := range products {
+		if horizon := product.LeadTimeDays + reviewDays; horizon > forecasting.MaxPeriods {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("lead_time_days + review_days of product %d is %d days (max %d)", product.ID, horizon, forecasting.MaxPeriods)})
+			return
+		}
+	}
+
+	// Histori sampai kemarin (hari ini belum lengkap)
+	now := time.Now().In(AppLocation)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, AppLocation)
+	start := today.AddDate(0, 0, -lookbackDays)
+	history := DateRange{Start: &start, End: &today}
+
+	recommendations := make([]RestockRecommendation, 0, len(products))
+	for _, product := range products {
+		rec := RestockRecommendation{
+			ProductID:    product.ID,
+			NamaProduk:   product.Nama,
+			Stok:         product.Stok,
+			LeadTimeDays: product.LeadTimeDays,
+			SafetyStock:  product.SafetyStock,
+		}
+		if err := ctrl.recommend(c.Request.Context(), forecaster, history, reviewDays, &rec); err != nil {
+			rec.Error = err.Error()
+		}
+		recommendations = append(recommendations, rec)
+	}
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return recommendations[i].NeedsReorder && !recommendations[j].NeedsReorder
+	})
+	setPaginationHeaders(c, pagination, total)
+	c.JSON(http.StatusOK, recommendations)
+}
+
+// recommend forecast demand satu produk & isi reorder point / saran order
+func (ctrl *InventoryController) recommend(ctx context.Context, forecaster forecasting.Forecaster, history DateRange, reviewDays int, rec *RestockRecommendation) error {
+	series, err := dailyQuantitySeries(ctrl.DB, []uint{rec.ProductID}, history)
+	if err != nil {
+		return err
+	}
+	horizon := rec.LeadTimeDays + reviewDays
+	daily := make([]float64, horizon)
+
+	if len(series) < minHistoryDays {
+		// Histori kurang buat forecast: pakai rata-rata penjualan yang ada
+		rec.Model = averageModel
+		var sum float64
+		for _, p := range series {
+			sum += p.Value
+		}
+		if len(series) > 0 {
+			for i := range daily {
+				daily[i] = sum / float64(len(series))
+			}
+		}
+	} else if horizon > 0 {
+		holidays, err := loadHolidays(ctrl.DB.WithContext(ctx), series, horizon)
+		if err != nil {
+			return err
+		}
+		result, err := forecaster.Forecast(ctx, forecasting.Request{Series: series, Periods: horizon, Holidays: holidays})
+		if err != nil {
+			return err
+		}
+		rec.Model = result.Model
+		rec.FallbackReason = result.FallbackReason
+		for i, p := range result.Forecast {
+			if i < horizon && p.Yhat != nil {
+				daily[i] = math.Max(*p.Yhat, 0)
+			}
+		}
+	}
+
+	var leadDemand, totalDemand float64
+	for i, d := range daily {
+		if i < rec.LeadTimeDays {
+			leadDemand += d
+		}
+		totalDemand += d
+	}
+	if horizon > 0 {
+		rec.AvgDailyDemand = totalDemand / float64(horizon)
+	}
+	rec.LeadTimeDemand = leadDemand
+	rec.ReorderPoint = int(math.Ceil(leadDemand)) + rec.SafetyStock
+	rec.OrderUpTo = int(math.Ceil(totalDemand)) + rec.SafetyStock
+	rec.NeedsReorder = rec.Stok <= rec.ReorderPoint && rec.OrderUpTo > rec.Stok
+	if rec.NeedsReorder {
+		rec.SuggestedOrderQty = rec.OrderUpTo - rec.Stok
+	}
+	if rec.AvgDailyDemand > 0 {
+		cover := float64(rec.Stok) / rec.AvgDailyDemand
+		rec.DaysOfCover = &cover
+	}
+	return nil
+}
+
+// queryIntInRange baca query int opsional dengan default & batas
+func queryIntInRange(c *gin.Context, key string, fallback, lower, upper int) (int, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v < lower || v > upper {
+		return 0, fmt.Errorf("Invalid %s (must be number between %d and %d)", key, lower, upper)
+	}
+	return v, nil
+}
@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRecommendationsRejectsHorizonOverMaxPeriods(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fake := newFakeDB(t, func(sql string) fakeResult {
+		if strings.Contains(sql, "count(*)") {
+			return fakeResult{columns: []string{"count"}, rows: [][]driver.Value{{int64(1)}}}
+		}
+		return fakeResult{
+			columns: []string{"id", "store_id", "nama", "harga", "stok", "lead_time_days"},
+			rows:    [][]driver.Value{{int64(7), int64(1), "Kopi", 15000.0, int64(3), int64(360)}},
+		}
+	})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/inventory/recommendations?review_days=7", nil)
+
+	NewInventoryController(fake.db, nil).Recommendations(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d (%s)", w.Code, http.StatusBadRequest, w.Body)
+	}
+	if !strings.Contains(w.Body.String(), "367 days (max 365)") {
+		t.Errorf("body = %s, want the horizon in the error", w.Body)
+	}
+	if history := fake.Matching(`FROM "transactions"`); len(history) > 0 {
+		t.Errorf("sales history queried before rejecting the horizon: %q", history)
+	}
+}
@@ -0,0 +1,392 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"backend-penjualan/audit"
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OrderController godoc
+// @Description Order controller handles multi-item orders (order header + order items)
+type OrderController struct {
+	DB *gorm.DB
+}
+
+func NewOrderController(db *gorm.DB) *OrderController {
+	return &OrderController{DB: db}
+}
+
+var errOrderNotFound = errors.New("order not found")
+
+// orderSortFields whitelist field yang boleh dipakai di ?sort=
+var orderSortFields = map[string]string{
+	"id":             "id",
+	"nama_pembeli":   "nama_pembeli",
+	"total_quantity": "total_quantity",
+	"total":          "total",
+	"created_at":     "created_at",
+	"updated_at":     "updated_at",
+}
+
+// OrderItemInput satu baris produk di request order
+type OrderItemInput struct {
+	ProductID int64 `json:"product_id"`
+	Quantity  int64 `json:"quantity"`
+}
+
+// CreateOrderInput body buat POST /orders
+type CreateOrderInput struct {
+	NamaPembeli string           `json:"nama_pembeli"`
+	CustomerID  *int64           `json:"customer_id"`
+	Items       []OrderItemInput `json:"items"`
+}
+
+// UpdateOrderInput body buat PATCH /orders/:id (items kalau diisi akan mengganti semua baris lama)
+type UpdateOrderInput struct {
+	NamaPembeli *string          `json:"nama_pembeli"`
+	CustomerID  *int64           `json:"customer_id"`
+	Items       []OrderItemInput `json:"items"`
+}
+
+// validateOrderItems cek item kosong, product_id/quantity invalid & product_id dobel
+func validateOrderItems(items []OrderItemInput) error {
+	if len(items) == 0 {
+		return errors.New("Items required (at least 1)")
+	}
+	seen := map[int64]bool{}
+	for i, item := range items {
+		if item.ProductID <= 0 {
+			return fmt.Errorf("Item %d: product ID required & positive", i+1)
+		}
+		if item.Quantity <= 0 {
+			return fmt.Errorf("Item %d: quantity must be at least 1", i+1)
+		}
+		if seen[item.ProductID] {
+			return fmt.Errorf("Item %d: duplicate product_id %d", i+1, item.ProductID)
+		}
+		seen[item.ProductID] = true
+	}
+	return nil
+}
+
+// buildOrderItems kurangi stok tiap produk & snapshot harga. Urut berdasarkan product_id
+// biar urutan row lock konsisten (hindari deadlock antar order). Produk toko lain dianggap gak ada.
+func buildOrderItems(tx *gorm.DB, storeID uint, inputs []OrderItemInput) ([]models.OrderItem, uint, float64, error) {
+	sorted := make([]OrderItemInput, len(inputs))
+	copy(sorted, inputs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ProductID < sorted[j].ProductID })
+
+	var items []models.OrderItem
+	var totalQuantity uint
+	var total float64
+	for _, input := range sorted {
+		product, err := reserveStock(tx, storeID, uint(input.ProductID), int(input.Quantity))
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		subtotal := float64(input.Quantity) * product.Harga
+		items = append(items, models.OrderItem{
+			ProductID: product.ID,
+			Quantity:  uint(input.Quantity),
+			Harga:     product.Harga,
+			Subtotal:  subtotal,
+		})
+		totalQuantity += uint(input.Quantity)
+		total += subtotal
+	}
+	return items, totalQuantity, total, nil
+}
+
+func (ctrl *OrderController) preloadItems() *gorm.DB {
+	return ctrl.withItems(ctrl.DB)
+}
+
+func (ctrl *OrderController) withItems(query *gorm.DB) *gorm.DB {
+	return query.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("order_items.id")
+	}).Preload("Items.Product", withDeleted).Preload("Customer", withDeleted) // Produk/customer yang sudah dihapus tetap tampil
+}
+
+// GetAll godoc
+// @Summary Get all orders
+// @Description Retrieve paginated list of multi-item orders (with items & products) of the active store, optional search by buyer name. Total rows returned in X-Total-Count header.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param search query string false "Search by buyer name (partial)"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (max 500; default 50 when page is set, all rows when neither page nor limit is set)"
+// @Param sort query string false "Sort fields, comma separated, prefix - for descending (id, nama_pembeli, total_quantity, total, created_at, updated_at)"
+// @Success 200 {array} models.Order "List of orders"
+// @Header 200 {integer} X-Total-Count "Total orders matching the filter"
+// @Failure 400 {object} map[string]string "Invalid pagination or sort"
+// @Failure 500 {object} map[string]string "Query failed"
+// @Router /orders [get]
+func (ctrl *OrderController) GetAll(c *gin.Context) {
+	pagination, err := parseLegacyPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sortClause, err := parseSort(c, orderSortFields, "id ASC")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var orders []models.Order
+	query := ctrl.DB.Model(&models.Order{}).Scopes(storeScope(c, "store_id"))
+	if search := c.Query("search"); search != "" {
+		query = query.Where("nama_pembeli ILIKE ?", "%"+search+"%")
+	}
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		return
+	}
+	if err := ctrl.withItems(query).Order(sortClause).Order("id").
+		Offset(pagination.Offset()).Limit(pagination.Limit).Find(&orders).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		return
+	}
+	setPaginationHeaders(c, pagination, total)
+	c.JSON(http.StatusOK, orders)
+}
+
+// GetByID godoc
+// @Summary Get order by ID
+// @Description Retrieve a specific order by ID with its items and products
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {object} models.Order "Order details"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 404 {object} map[string]string "Order not found"
+// @Failure 500 {object} map[string]string "Query failed"
+// @Router /orders/{id} [get]
+func (ctrl *OrderController) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+	var order models.Order
+	if err := ctrl.preloadItems().Scopes(storeScope(c, "store_id")).First(&order, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, order)
+}
+
+// Create godoc
+// @Summary Create a new order
+// @Description Create a multi-item order in the active store: snapshot unit price per item, compute line & order totals, decrement stock for every product; recorded in the audit log. All products must belong to the store; head office must send X-Store-ID.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param input body CreateOrderInput true "Order input (nama_pembeli and/or customer_id, items[product_id, quantity])"
+// @Success 201 {object} models.Order "Created order (with items)"
+// @Failure 400 {object} map[string]string "Validation error"
+// @Failure 404 {object} map[string]string "Product or customer not found"
+// @Failure 409 {object} map[string]interface{} "Insufficient stock"
+// @Failure 500 {object} map[string]string "Create failed"
+// @Router /orders [post]
+func (ctrl *OrderController) Create(c *gin.Context) {
+	var input CreateOrderInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid input: %v", err)})
+		return
+	}
+	if err := validateOrderItems(input.Items); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	customerID, namaPembeli, err := resolveCustomer(ctrl.DB, input.CustomerID, input.NamaPembeli)
+	if err != nil {
+		respondCustomerError(c, err)
+		return
+	}
+	storeID, ok := requireStore(c, ctrl.DB)
+	if !ok {
+		return
+	}
+
+	var order models.Order
+	err = ctrl.DB.Transaction(func(tx *gorm.DB) error {
+		items, totalQuantity, total, err := buildOrderItems(tx, storeID, input.Items)
+		if err != nil {
+			return err
+		}
+		order = models.Order{
+			StoreID:       storeID,
+			NamaPembeli:   namaPembeli,
+			CustomerID:    customerID,
+			TotalQuantity: totalQuantity,
+			Total:         total,
+			Items:         items,
+		}
+		if err := tx.Create(&order).Error; err != nil {
+			return err
+		}
+		return audit.Record(tx, c, audit.Entry{
+			Action:   models.AuditActionCreate,
+			Entity:   audit.EntityOrder,
+			EntityID: order.ID,
+			StoreID:  &order.StoreID,
+			After:    order,
+		})
+	})
+	if err != nil {
+		respondStockError(c, err, "Create")
+		return
+	}
+
+	if err := ctrl.preloadItems().First(&order, order.ID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Preload failed: %v", err.Error())})
+		return
+	}
+	c.JSON(http.StatusCreated, order)
+}
+
+// Update godoc
+// @Summary Update an order partially
+// @Description Update nama_pembeli/customer_id and/or replace all items (old items' stock is restored, new items reserve stock and snapshot current prices)
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param input body UpdateOrderInput true "Fields to update (optional)"
+// @Success 200 {object} models.Order "Updated order (with items)"
+// @Failure 400 {object} map[string]string "Validation error"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin or manager role)"
+// @Failure 404 {object} map[string]string "Order, product or customer not found"
+// @Failure 409 {object} map[string]interface{} "Insufficient stock"
+// @Failure 500 {object} map[string]string "Update failed"
+// @Router /orders/{id} [patch]
+func (ctrl *OrderController) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var input UpdateOrderInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid input: %v", err)})
+		return
+	}
+	if input.NamaPembeli != nil && *input.NamaPembeli == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Nama pembeli cannot be empty"})
+		return
+	}
+	if input.Items != nil {
+		if err := validateOrderItems(input.Items); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if input.NamaPembeli == nil && input.CustomerID == nil && input.Items == nil {
+		c.JSON(http.StatusOK, gin.H{"message": "No changes provided"})
+		return
+	}
+
+	updates := map[string]interface{}{}
+	if input.NamaPembeli != nil {
+		updates["nama_pembeli"] = *input.NamaPembeli
+	}
+	if input.CustomerID != nil {
+		nama := ""
+		if input.NamaPembeli != nil {
+			nama = *input.NamaPembeli
+		}
+		customerID, namaPembeli, err := resolveCustomer(ctrl.DB, input.CustomerID, nama)
+		if err != nil {
+			respondCustomerError(c, err)
+			return
+		}
+		updates["customer_id"] = *customerID
+		updates["nama_pembeli"] = namaPembeli
+	}
+
+	var order models.Order
+	err = ctrl.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Scopes(storeScope(c, "store_id")).Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items").First(&order, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errOrderNotFound
+			}
+			return err
+		}
+
+		if input.Items != nil {
+			// Kembalikan stok item lama, hapus, lalu reserve item baru
+			for _, item := range order.Items {
+				if err := releaseStock(tx, item.ProductID, int(item.Quantity)); err != nil {
+					return err
+				}
+			}
+			if err := tx.Where("order_id = ?", order.ID).Delete(&models.OrderItem{}).Error; err != nil {
+				return err
+			}
+			items, totalQuantity, total, err := buildOrderItems(tx, order.StoreID, input.Items)
+			if err != nil {
+				return err
+			}
+			for i := range items {
+				items[i].OrderID = order.ID
+			}
+			if err := tx.Create(&items).Error; err != nil {
+				return err
+			}
+			updates["total_quantity"] = totalQuantity
+			updates["total"] = total
+		}
+		// Jangan lewat Model(&order): order.Items masih berisi item lama hasil Preload dan bakal di-upsert balik oleh GORM
+		if err := tx.Model(&models.Order{}).Where("id = ?", order.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+		var updated models.Order
+		if err := tx.First(&updated, order.ID).Error; err != nil {
+			return err
+		}
+		// Item gak ikut di-diff (relasi), perubahan item kelihatan dari total & total_quantity
+		return audit.Record(tx, c, audit.Entry{
+			Action:   models.AuditActionUpdate,
+			Entity:   audit.EntityOrder,
+			EntityID: order.ID,
+			StoreID:  &order.StoreID,
+			Before:   order,
+			After:    updated,
+		})
+	})
+	if err != nil {
+		if errors.Is(err, errOrderNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+			return
+		}
+		respondStockError(c, err, "Update")
+		return
+	}
+
+	order = models.Order{}
+	if err := ctrl.preloadItems().First(&order, id).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh order"})
+		return
+	}
+	c.JSON(http.StatusOK, order)
+}
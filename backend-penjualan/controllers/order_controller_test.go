@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestOrderUpdateRecordsAudit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	nama := "Budi"
+	fake := newFakeDB(t, func(sql string) fakeResult {
+		switch {
+		case strings.HasPrefix(sql, `UPDATE "orders"`):
+			nama = "Sari"
+			return fakeResult{rowsAffected: 1}
+		case strings.Contains(sql, `FROM "orders"`):
+			return fakeResult{
+				columns: []string{"id", "store_id", "nama_pembeli", "total_quantity", "total"},
+				rows:    [][]driver.Value{{int64(3), int64(1), nama, int64(2), 30000.0}},
+			}
+		}
+		return fakeResult{columns: []string{"id"}}
+	})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPatch, "/orders/3", strings.NewReader(`{"nama_pembeli":"Sari"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: "3"}}
+
+	NewOrderController(fake.db).Update(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (%s)", w.Code, http.StatusOK, w.Body)
+	}
+	statements := fake.Statements()
+	commit := -1
+	audit := -1
+	for i, statement := range statements {
+		switch {
+		case statement == "COMMIT":
+			commit = i
+		case containsAll(statement, `INSERT INTO "audit_logs"`, `'order'`, `'update'`, `"before":"Budi"`, `"after":"Sari"`):
+			audit = i
+		}
+	}
+	if audit < 0 || commit < audit {
+		t.Fatalf("statements = %q, want the order audit log inserted before COMMIT", statements)
+	}
+}
@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestProductLeadTimeDaysBounded(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		call   func(*ProductController, *gin.Context)
+	}{
+		{"create", http.MethodPost, `{"nama":"Kopi","harga":15000,"stok":1,"lead_time_days":366}`, (*ProductController).Create},
+		{"update", http.MethodPut, `{"lead_time_days":366}`, (*ProductController).Update},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeDB(t, func(string) fakeResult { return productRow(7, 1, "Kopi", 15000, 1) })
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(tt.method, "/products/7", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = gin.Params{{Key: "id", Value: "7"}}
+
+			tt.call(NewProductController(fake.db), c)
+
+			if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "lead_time_days max 365") {
+				t.Fatalf("status = %d body = %s, want 400 lead_time_days max 365", w.Code, w.Body)
+			}
+			if writes := append(fake.Matching("INSERT"), fake.Matching("UPDATE")...); len(writes) > 0 {
+				t.Errorf("product written despite invalid lead_time_days: %q", writes)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 500
+	noPageLimit      = -1 // Limit(-1) di GORM = tanpa LIMIT
+	dateLayout       = "2006-01-02"
+	appTimezone      = "Asia/Jakarta"
+)
+
+// AppLocation timezone toko (sama dengan TimeZone di DSN), dipakai buat filter tanggal & laporan
+var AppLocation = loadAppLocation()
+
+func loadAppLocation() *time.Location {
+	loc, err := time.LoadLocation(appTimezone)
+	if err != nil {
+		return time.FixedZone("WIB", 7*60*60)
+	}
+	return loc
+}
+
+// Pagination hasil parse query ?page=&limit=
+type Pagination struct {
+	Page  int
+	Limit int
+}
+
+func (p Pagination) Offset() int {
+	if p.Limit == noPageLimit {
+		return 0
+	}
+	return (p.Page - 1) * p.Limit
+}
+
+// parsePagination baca ?page= (mulai 1) & ?limit= (default 50, max 500)
+func parsePagination(c *gin.Context) (Pagination, error) {
+	p := Pagination{Page: 1, Limit: defaultPageLimit}
+	if pageStr := c.Query("page"); pageStr != "" {
+		page, err := strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			return p, fmt.Errorf("Invalid page (must be number >= 1)")
+		}
+		p.Page = page
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > maxPageLimit {
+			return p, fmt.Errorf("Invalid limit (must be number between 1 and %d)", maxPageLimit)
+		}
+		p.Limit = limit
+	}
+	return p, nil
+}
+
+// parseLegacyPagination buat list yang dulu mengembalikan semua baris (products, transactions, orders, customers):
+// tanpa ?page= & ?limit= tetap semua baris biar client lama gak terpotong di 50 baris
+func parseLegacyPagination(c *gin.Context) (Pagination, error) {
+	if c.Query("page") == "" && c.Query("limit") == "" {
+		return Pagination{Page: 1, Limit: noPageLimit}, nil
+	}
+	return parsePagination(c)
+}
+
+// parseSort baca ?sort=field,-field (prefix "-" = descending). Field harus ada di whitelist
+// allowed (nama field di API -> kolom SQL), biar gak bisa SQL injection lewat ORDER BY.
+func parseSort(c *gin.Context, allowed map[string]string, fallback string) (string, error) {
+	sortStr := strings.TrimSpace(c.Query("sort"))
+	if sortStr == "" {
+		return fallback, nil
+	}
+	var clauses []string
+	for _, field := range strings.Split(sortStr, ",") {
+		field = strings.TrimSpace(field)
+		direction := "ASC"
+		if strings.HasPrefix(field, "-") {
+			direction = "DESC"
+			field = strings.TrimPrefix(field, "-")
+		}
+		column, ok := allowed[field]
+		if !ok {
+			return "", fmt.Errorf("Invalid sort field %q", field)
+		}
+		clauses = append(clauses, column+" "+direction)
+	}
+	return strings.Join(clauses, ", "), nil
+}
+
+// setPaginationHeaders total data (sebelum limit) dikirim lewat header biar body tetap array
+func setPaginationHeaders(c *gin.Context, p Pagination, total int64) {
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	c.Header("X-Page", strconv.Itoa(p.Page))
+	if p.Limit != noPageLimit {
+		c.Header("X-Limit", strconv.Itoa(p.Limit))
+	}
+}
+
+// DateRange rentang tanggal [Start, End) di AppLocation; End sudah digeser ke awal hari berikutnya
+type DateRange struct {
+	Start *time.Time
+	End   *time.Time
+}
+
+// parseDateRange baca ?start_date= & ?end_date= (YYYY-MM-DD, inclusive)
+func parseDateRange(c *gin.Context) (DateRange, error) {
+	return parseDateRangeValues(c.Query("start_date"), c.Query("end_date"))
+}
+
+// parseDateRangeValues sama dengan parseDateRange tapi dari string (buat body JSON)
+func parseDateRangeValues(startStr, endStr string) (DateRange, error) {
+	var r DateRange
+	if startStr != "" {
+		start, err := time.ParseInLocation(dateLayout, startStr, AppLocation)
+		if err != nil {
+			return r, fmt.Errorf("Invalid start_date format. Use YYYY-MM-DD")
+		}
+		r.Start = &start
+	}
+	if endStr != "" {
+		end, err := time.ParseInLocation(dateLayout, endStr, AppLocation)
+		if err != nil {
+			return r, fmt.Errorf("Invalid end_date format. Use YYYY-MM-DD")
+		}
+		end = end.AddDate(0, 0, 1) // inclusive: sampai akhir hari end_date
+		r.End = &end
+	}
+	if r.Start != nil && r.End != nil && !r.Start.Before(*r.End) {
+		return r, fmt.Errorf("start_date must be before or equal to end_date")
+	}
+	return r, nil
+}
+
+// parseFloatRange baca ?min_<name>= & ?max_<name>=
+func parseFloatRange(c *gin.Context, name string) (lower, upper *float64, err error) {
+	for _, bound := range []string{"min", "max"} {
+		key := bound + "_" + name
+		raw := c.Query(key)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil || v < 0 {
+			return nil, nil, fmt.Errorf("Invalid %s (must be non-negative number)", key)
+		}
+		if bound == "min" {
+			lower = &v
+		} else {
+			upper = &v
+		}
+	}
+	if lower != nil && upper != nil && *lower > *upper {
+		return nil, nil, fmt.Errorf("min_%s must be less than or equal to max_%s", name, name)
+	}
+	return lower, upper, nil
+}
+
+// parseIDList baca ID yang bisa diulang (?product_id=1&product_id=2) atau dipisah koma (?product_id=1,2)
+func parseIDList(c *gin.Context, key string) ([]uint, error) {
+	var ids []uint
+	for _, raw := range c.QueryArray(key) {
+		for _, part := range strings.Split(raw, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			id, err := strconv.ParseUint(part, 10, 64)
+			if err != nil || id == 0 {
+				return nil, fmt.Errorf("Invalid %s format (must be number)", key)
+			}
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids, nil
+}
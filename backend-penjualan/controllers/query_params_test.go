@@ -0,0 +1,244 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// queryContext gin.Context buat GET /?<query>, response-nya bisa dicek lewat recorder
+func queryContext(query string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+	return c, w
+}
+
+func TestParsePagination(t *testing.T) {
+	tests := []struct {
+		query      string
+		want       Pagination
+		wantOffset int
+		wantErr    bool
+	}{
+		{"", Pagination{Page: 1, Limit: defaultPageLimit}, 0, false},
+		{"page=3&limit=20", Pagination{Page: 3, Limit: 20}, 40, false},
+		{"limit=500", Pagination{Page: 1, Limit: maxPageLimit}, 0, false},
+		{"page=0", Pagination{}, 0, true},
+		{"page=abc", Pagination{}, 0, true},
+		{"limit=0", Pagination{}, 0, true},
+		{"limit=501", Pagination{}, 0, true},
+		{"limit=-1", Pagination{}, 0, true},
+	}
+	for _, tt := range tests {
+		c, _ := queryContext(tt.query)
+		got, err := parsePagination(c)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parsePagination(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if got != tt.want || got.Offset() != tt.wantOffset {
+			t.Errorf("parsePagination(%q) = %+v offset %d, want %+v offset %d", tt.query, got, got.Offset(), tt.want, tt.wantOffset)
+		}
+	}
+}
+
+func TestParseLegacyPagination(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    Pagination
+		wantErr bool
+	}{
+		{"", Pagination{Page: 1, Limit: noPageLimit}, false},
+		{"page=2", Pagination{Page: 2, Limit: defaultPageLimit}, false},
+		{"limit=10", Pagination{Page: 1, Limit: 10}, false},
+		{"limit=1000", Pagination{}, true},
+	}
+	for _, tt := range tests {
+		c, _ := queryContext(tt.query)
+		got, err := parseLegacyPagination(c)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseLegacyPagination(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseLegacyPagination(%q) = %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+	// Tanpa limit: offset 0 biar GORM gak bikin OFFSET dari Limit -1
+	if offset := (Pagination{Page: 3, Limit: noPageLimit}).Offset(); offset != 0 {
+		t.Errorf("Offset() without limit = %d, want 0", offset)
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	allowed := map[string]string{"id": "id", "nama": "nama", "created_at": "created_at"}
+	tests := []struct {
+		query   string
+		want    string
+		wantErr bool
+	}{
+		{"", "id DESC", false},
+		{"sort=nama", "nama ASC", false},
+		{"sort=-created_at,%20id", "created_at DESC, id ASC", false},
+		{"sort=harga", "", true},
+		{"sort=id%3BDROP%20TABLE%20products", "", true},
+		{"sort=nama%20DESC", "", true},
+		{"sort=id,", "", true},
+	}
+	for _, tt := range tests {
+		c, _ := queryContext(tt.query)
+		got, err := parseSort(c, allowed, "id DESC")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSort(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseSort(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSetPaginationHeaders(t *testing.T) {
+	tests := []struct {
+		name      string
+		p         Pagination
+		wantLimit string
+	}{
+		{"paginated", Pagination{Page: 2, Limit: 20}, "20"},
+		{"all rows", Pagination{Page: 1, Limit: noPageLimit}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, w := queryContext("")
+			setPaginationHeaders(c, tt.p, 42)
+			if got := w.Header().Get("X-Total-Count"); got != "42" {
+				t.Errorf("X-Total-Count = %q, want 42", got)
+			}
+			if got, want := w.Header().Get("X-Page"), strconv.Itoa(tt.p.Page); got != want {
+				t.Errorf("X-Page = %q, want %q", got, want)
+			}
+			if got := w.Header().Get("X-Limit"); got != tt.wantLimit {
+				t.Errorf("X-Limit = %q, want %q", got, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestParseDateRangeValues(t *testing.T) {
+	day := func(y int, m time.Month, d int) string {
+		return time.Date(y, m, d, 0, 0, 0, 0, AppLocation).Format(time.RFC3339)
+	}
+	format := func(v *time.Time) string {
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	}
+	tests := []struct {
+		name      string
+		start     string
+		end       string
+		wantStart string
+		wantEnd   string
+		wantErr   bool
+	}{
+		{"no range", "", "", "", "", false},
+		{"start only", "2025-01-01", "", day(2025, 1, 1), "", false},
+		{"end is inclusive", "", "2025-01-31", "", day(2025, 2, 1), false},
+		{"same day", "2025-01-05", "2025-01-05", day(2025, 1, 5), day(2025, 1, 6), false},
+		{"start after end", "2025-01-06", "2025-01-05", "", "", true},
+		{"invalid start", "01/01/2025", "", "", "", true},
+		{"invalid end", "", "2025-13-01", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDateRangeValues(tt.start, tt.end)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if format(got.Start) != tt.wantStart || format(got.End) != tt.wantEnd {
+				t.Errorf("range = [%s, %s), want [%s, %s)", format(got.Start), format(got.End), tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestParseFloatRange(t *testing.T) {
+	tests := []struct {
+		query     string
+		wantLower string
+		wantUpper string
+		wantErr   bool
+	}{
+		{"", "<nil>", "<nil>", false},
+		{"min_total=1000", "1000", "<nil>", false},
+		{"min_total=10&max_total=10", "10", "10", false},
+		{"min_total=20&max_total=10", "", "", true},
+		{"max_total=-1", "", "", true},
+		{"min_total=abc", "", "", true},
+	}
+	for _, tt := range tests {
+		c, _ := queryContext(tt.query)
+		lower, upper, err := parseFloatRange(c, "total")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseFloatRange(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if got := formatFloatPtr(lower); got != tt.wantLower {
+			t.Errorf("parseFloatRange(%q) lower = %s, want %s", tt.query, got, tt.wantLower)
+		}
+		if got := formatFloatPtr(upper); got != tt.wantUpper {
+			t.Errorf("parseFloatRange(%q) upper = %s, want %s", tt.query, got, tt.wantUpper)
+		}
+	}
+}
+
+func formatFloatPtr(v *float64) string {
+	if v == nil {
+		return "<nil>"
+	}
+	return strconv.FormatFloat(*v, 'g', -1, 64)
+}
+
+func TestParseIDList(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    string
+		wantErr bool
+	}{
+		{"", "[]", false},
+		{"product_id=3", "[3]", false},
+		{"product_id=1&product_id=2", "[1 2]", false},
+		{"product_id=1,%202,,3", "[1 2 3]", false},
+		{"product_id=0", "", true},
+		{"product_id=-1", "", true},
+		{"product_id=1,abc", "", true},
+	}
+	for _, tt := range tests {
+		c, _ := queryContext(tt.query)
+		got, err := parseIDList(c, "product_id")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseIDList(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && fmt.Sprint(got) != tt.want {
+			t.Errorf("parseIDList(%q) = %v, want %s", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"backend-penjualan/auth"
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ReportController godoc
+// @Description Report controller handles aggregated sales reports
+type ReportController struct {
+	DB *gorm.DB
+}
+
+func NewReportController(db *gorm.DB) *ReportController {
+	return &ReportController{DB: db}
+}
+
+// reportBuckets group_by yang didukung -> (unit date_trunc, format label periode)
+var reportBuckets = map[string][2]string{
+	"day":   {"day", "YYYY-MM-DD"},
+	"week":  {"week", "YYYY-MM-DD"}, // Label = hari Senin awal minggu
+	"month": {"month", "YYYY-MM"},
+}
+
+// SalesReportBucket agregat penjualan satu periode (dan satu produk / toko kalau split=product / store)
+type SalesReportBucket struct {
+	Period           string  `json:"period"`
+	ProductID        *uint   `json:"product_id,omitempty"`
+	ProductNama      *string `json:"product_nama,omitempty"`
+	StoreID          *uint   `json:"store_id,omitempty"`
+	StoreNama        *string `json:"store_nama,omitempty"`
+	Revenue          float64 `json:"revenue"`
+	Quantity         int64   `json:"quantity"`
+	TransactionCount int64   `json:"transaction_count"`
+}
+
+// SalesReportTotals agregat seluruh rentang laporan
+type SalesReportTotals struct {
+	Revenue          float64 `json:"revenue"`
+	Quantity         int64   `json:"quantity"`
+	TransactionCount int64   `json:"transaction_count"`
+}
+
+// SalesReportResponse response GET /reports/sales
+type SalesReportResponse struct {
+	GroupBy   string              `json:"group_by"`
+	Timezone  string              `json:"timezone"`
+	StoreID   *uint               `json:"store_id,omitempty"` // Kosong = konsolidasi semua toko
+	Split     string              `json:"split,omitempty"`
+	StartDate string              `json:"start_date,omitempty"`
+	EndDate   string              `json:"end_date,omitempty"`
+	Totals    SalesReportTotals   `json:"totals"`
+	Buckets   []SalesReportBucket `json:"buckets"`
+}
+
+// Sales godoc
+// @Summary Sales summary report
+// @Description Revenue, quantity and transaction count grouped by day/week/month (Asia/Jakarta), optionally split per product or per store. Covers the active store (X-Store-ID or the user's store); head-office users without X-Store-ID get a consolidated report across all stores (use split=store for a per-branch breakdown). Accepts the same filters as GET /transactions.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param group_by query string false "Time bucket (default day)" Enums(day, week, month)
+// @Param split query string false "Split each bucket per product or per store" Enums(product, store)
+// @Param product_id query []int false "Filter by product ID (repeat or comma separated for multiple)" collectionFormat(multi)
+// @Param start_date query string false "Start date, inclusive (YYYY-MM-DD)"
+// @Param end_date query string false "End date, inclusive (YYYY-MM-DD)"
+// @Param date_field query string false "Date column used for bucketing & range: created_at (default) or updated_at" Enums(created_at, updated_at)
+// @Success 200 {object} SalesReportResponse "Sales report"
+// @Failure 400 {object} map[string]string "Invalid parameter"
+// @Failure 500 {object} map[string]string "Query failed"
+// @Router /reports/sales [get]
+func (ctrl *ReportController) Sales(c *gin.Context) {
+	groupBy := c.DefaultQuery("group_by", "day")
+	bucket, ok := reportBuckets[groupBy]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid group_by (use day, week or month)"})
+		return
+	}
+	split := c.Query("split")
+	if split != "" && split != "product" && split != "store" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid split (use product or store)"})
+		return
+	}
+
+	query, err := applyTransactionFilters(c, ctrl.DB.Model(&models.Transaction{}))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Kolom tanggal & unit sudah di-whitelist, aman di-embed ke SQL
+	dateColumn := "transactions.created_at"
+	if c.Query("date_field") == "updated_at" {
+		dateColumn = "transactions.updated_at"
+	}
+	periodExpr := fmt.Sprintf("to_char(date_trunc('%s', %s AT TIME ZONE '%s'), '%s')",
+		bucket[0], dateColumn, appTimezone, bucket[1])
+
+	selects := []string{
+		periodExpr + " AS period",
+		"COALESCE(SUM(transactions.total), 0) AS revenue",
+		"COALESCE(SUM(transactions.quantity), 0) AS quantity",
+		"COUNT(*) AS transaction_count",
+	}
+	groups := "period"
+	orders := "period"
+	if split == "product" {
+		// Alias sendiri biar gak bentrok sama JOIN products dari filter search
+		query = query.Joins("LEFT JOIN products report_products ON report_products.id = transactions.product_id")
+		selects = append(selects, "transactions.product_id AS product_id", "report_products.nama AS product_nama")
+		groups = "period, transactions.product_id, report_products.nama"
+		orders = "period, transactions.product_id"
+	}
+	if split == "store" {
+		query = query.Joins("LEFT JOIN stores report_stores ON report_stores.id = transactions.store_id")
+		selects = append(selects, "transactions.store_id AS store_id", "report_stores.nama AS store_nama")
+		groups = "period, transactions.store_id, report_stores.nama"
+		orders = "period, transactions.store_id"
+	}
+
+	buckets := []SalesReportBucket{}
+	if err := query.Select(selects).Group(groups).Order(orders).Scan(&buckets).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		return
+	}
+
+	resp := SalesReportResponse{
+		GroupBy:   groupBy,
+		Timezone:  appTimezone,
+		StoreID:   auth.CurrentStoreID(c),
+		Split:     split,
+		StartDate: c.Query("start_date"),
+		EndDate:   c.Query("end_date"),
+		Buckets:   buckets,
+	}
+	for _, b := range buckets {
+		resp.Totals.Revenue += b.Revenue
+		resp.Totals.Quantity += b.Quantity
+		resp.Totals.TransactionCount += b.TransactionCount
+	}
+	c.JSON(http.StatusOK, resp)
+}
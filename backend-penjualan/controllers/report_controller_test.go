@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestSalesReportGrouping(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantSQL   []string
+		wantSplit string
+	}{
+		{
+			name:    "default day",
+			query:   "",
+			wantSQL: []string{"date_trunc('day', transactions.created_at AT TIME ZONE 'Asia/Jakarta'), 'YYYY-MM-DD')", `GROUP BY "period" ORDER BY period`},
+		},
+		{
+			name:    "week on updated_at",
+			query:   "group_by=week&date_field=updated_at",
+			wantSQL: []string{"date_trunc('week', transactions.updated_at AT TIME ZONE 'Asia/Jakarta'), 'YYYY-MM-DD')"},
+		},
+		{
+			name:      "month per product",
+			query:     "group_by=month&split=product",
+			wantSQL:   []string{"'YYYY-MM')", "LEFT JOIN products report_products", "GROUP BY period, transactions.product_id, report_products.nama"},
+			wantSplit: "product",
+		},
+		{
+			name:      "per store",
+			query:     "split=store",
+			wantSQL:   []string{"LEFT JOIN stores report_stores", "GROUP BY period, transactions.store_id, report_stores.nama"},
+			wantSplit: "store",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeDB(t, func(string) fakeResult {
+				return fakeResult{
+					columns: []string{"period", "revenue", "quantity", "transaction_count"},
+					rows: [][]driver.Value{
+						{"2025-01-01", 30000.0, int64(3), int64(2)},
+						{"2025-01-02", 15000.0, int64(1), int64(1)},
+					},
+				}
+			})
+			c, w := queryContext(tt.query)
+
+			NewReportController(fake.db).Sales(c)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, http.StatusOK, w.Body)
+			}
+			if selects := fake.Matching(append([]string{`FROM "transactions"`}, tt.wantSQL...)...); len(selects) != 1 {
+				t.Errorf("statements = %q, want report query containing %q", fake.Statements(), tt.wantSQL)
+			}
+			var resp SalesReportResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Split != tt.wantSplit || len(resp.Buckets) != 2 {
+				t.Errorf("split = %q buckets = %d, want %q and 2 buckets", resp.Split, len(resp.Buckets), tt.wantSplit)
+			}
+			if want := (SalesReportTotals{Revenue: 45000, Quantity: 4, TransactionCount: 3}); resp.Totals != want {
+				t.Errorf("totals = %+v, want %+v", resp.Totals, want)
+			}
+		})
+	}
+}
+
+func TestSalesReportRejectsInvalidGrouping(t *testing.T) {
+	for _, query := range []string{"group_by=year", "group_by=day%3BDROP", "split=customer"} {
+		fake := newFakeDB(t, func(string) fakeResult { return fakeResult{} })
+		c, w := queryContext(query)
+
+		NewReportController(fake.db).Sales(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", query, w.Code, http.StatusBadRequest)
+		}
+		if statements := fake.Statements(); len(statements) > 0 {
+			t.Errorf("%s: statements = %q, want none", query, statements)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InsufficientStockError dikembalikan kalau penjualan bikin stok produk minus
+type InsufficientStockError struct {
+	ProductID uint
+	Nama      string
+	Available int
+	Requested int
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("Insufficient stock for product %d (%s): available %d, requested %d",
+		e.ProductID, e.Nama, e.Available, e.Requested)
+}
+
+// reserveStock kurangi stok produk toko storeID di dalam DB transaction (row lock FOR UPDATE),
+// tolak kalau stok gak cukup. Return product yang sudah di-lock (buat ambil harga).
+// Produk toko lain = ErrRecordNotFound sebelum stok dibandingkan, biar stoknya gak bocor lewat 409.
+func reserveStock(tx *gorm.DB, storeID, productID uint, quantity int) (models.Product, error) {
+	var product models.Product
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("store_id = ?", storeID).
+		First(&product, productID).Error; err != nil {
+		return product, err
+	}
+	if product.Stok < quantity {
+		return product, &InsufficientStockError{
+			ProductID: product.ID,
+			Nama:      product.Nama,
+			Available: product.Stok,
+			Requested: quantity,
+		}
+	}
+	if err := tx.Model(&product).Update("stok", gorm.Expr("stok - ?", quantity)).Error; err != nil {
+		return product, err
+	}
+	product.Stok -= quantity
+	return product, nil
+}
+
+// releaseStock kembalikan stok produk (transaksi dihapus / quantity dikurangi).
+// Unscoped biar stok produk yang sudah di trash tetap benar kalau nanti di-restore.
+func releaseStock(tx *gorm.DB, productID uint, quantity int) error {
+	return tx.Unscoped().Model(&models.Product{}).Where("id = ?", productID).
+		Update("stok", gorm.Expr("stok + ?", quantity)).Error
+}
+
+// respondStockError mapping error dari reserveStock/releaseStock ke HTTP response
+func respondStockError(c *gin.Context, err error, action string) {
+	var stockErr *InsufficientStockError
+	switch {
+	case errors.As(err, &stockErr):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":      stockErr.Error(),
+			"product_id": stockErr.ProductID,
+			"available":  stockErr.Available,
+			"requested":  stockErr.Requested,
+		})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s failed: %v", action, err.Error())})
+	}
+}
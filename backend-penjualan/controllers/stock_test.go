@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func TestReserveStockScopesLookupToStore(t *testing.T) {
+	// Produk 7 ada, tapi milik toko 1: lookup dengan store_id = 2 gak nemu apa-apa
+	fake := newFakeDB(t, func(sql string) fakeResult {
+		if strings.Contains(sql, `FROM "products"`) && strings.Contains(sql, "store_id = 1") {
+			return productRow(7, 1, "Kopi", 15000, 1)
+		}
+		return fakeResult{columns: []string{"id"}}
+	})
+
+	_, err := reserveStock(fake.db, 2, 7, 3)
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("reserveStock() error = %v, want ErrRecordNotFound (no stock details of another store)", err)
+	}
+	statements := fake.Statements()
+	if len(statements) != 1 || !containsAll(statements[0], "store_id = 2", `"products"."id" = 7`, "FOR UPDATE") {
+		t.Fatalf("statements = %q, want only the store-scoped locked lookup", statements)
+	}
+}
+
+func TestReserveStock(t *testing.T) {
+	tests := []struct {
+		name       string
+		stok       int
+		quantity   int
+		wantStok   int
+		wantUpdate bool
+	}{
+		{"enough stock", 5, 3, 2, true},
+		{"exactly the stock left", 3, 3, 0, true},
+		{"insufficient stock", 2, 3, 2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeDB(t, func(sql string) fakeResult {
+				if strings.HasPrefix(sql, "SELECT") {
+					return productRow(7, 1, "Kopi", 15000, tt.stok)
+				}
+				return fakeResult{rowsAffected: 1}
+			})
+
+			product, err := reserveStock(fake.db, 1, 7, tt.quantity)
+			updates := fake.Matching(`UPDATE "products" SET "stok"=stok - 3`, `"id" = 7`)
+			if !tt.wantUpdate {
+				var stockErr *InsufficientStockError
+				if !errors.As(err, &stockErr) {
+					t.Fatalf("reserveStock() error = %v, want *InsufficientStockError", err)
+				}
+				if stockErr.ProductID != 7 || stockErr.Available != tt.stok || stockErr.Requested != tt.quantity {
+					t.Errorf("error = %+v, want product 7 available %d requested %d", stockErr, tt.stok, tt.quantity)
+				}
+				if len(updates) > 0 {
+					t.Errorf("stock updated despite insufficient stock: %q", updates)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("reserveStock() error = %v", err)
+			}
+			if product.Stok != tt.wantStok {
+				t.Errorf("product.Stok = %d, want %d", product.Stok, tt.wantStok)
+			}
+			// Dikurangi relatif ke nilai di DB (stok - n), bukan ditimpa angka hasil hitungan di Go
+			if len(updates) != 1 {
+				t.Errorf("statements = %q, want one relative stock decrement", fake.Statements())
+			}
+			if locked := fake.Matching(`FROM "products"`, "FOR UPDATE"); len(locked) != 1 {
+				t.Errorf("statements = %q, want the product row locked before the check", fake.Statements())
+			}
+		})
+	}
+}
+
+func TestReleaseStock(t *testing.T) {
+	fake := newFakeDB(t, func(string) fakeResult { return fakeResult{rowsAffected: 1} })
+
+	if err := releaseStock(fake.db, 7, 4); err != nil {
+		t.Fatalf("releaseStock() error = %v", err)
+	}
+	updates := fake.Matching(`UPDATE "products" SET "stok"=stok + 4`, "id = 7")
+	if len(updates) != 1 {
+		t.Fatalf("statements = %q, want one relative stock increment", fake.Statements())
+	}
+	// Produk di trash tetap dikembalikan stoknya
+	if strings.Contains(updates[0], "deleted_at") {
+		t.Errorf("release limited to products that are not deleted: %q", updates[0])
+	}
+}
+
+func TestRespondStockError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantBody   string
+	}{
+		{"insufficient stock", &InsufficientStockError{ProductID: 7, Nama: "Kopi", Available: 2, Requested: 3}, http.StatusConflict, `"available":2`},
+		{"product not found", gorm.ErrRecordNotFound, http.StatusNotFound, "Product not found"},
+		{"other error", errors.New("boom"), http.StatusInternalServerError, "Create failed: boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			respondStockError(c, tt.err, "Create")
+
+			if w.Code != tt.wantStatus || !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("status = %d body = %s, want %d containing %q", w.Code, w.Body, tt.wantStatus, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"backend-penjualan/auth"
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// StoreController godoc
+// @Description Store controller handles branches (stores) that own products, transactions and orders
+type StoreController struct {
+	DB *gorm.DB
+}
+
+func NewStoreController(db *gorm.DB) *StoreController {
+	return &StoreController{DB: db}
+}
+
+var errInvalidStoreInput = errors.New("invalid store input")
+
+// StoreInput body buat create/update store (update: field null gak diubah)
+type StoreInput struct {
+	Kode   *string `json:"kode"` // Unik (case-insensitive), mis. JKT01
+	Nama   *string `json:"nama"`
+	Alamat *string `json:"alamat"`
+	Active *bool   `json:"active"` // false = gak bisa input produk/transaksi/order baru
+}
+
+// applyStoreInput validasi & copy field input ke store
+func applyStoreInput(store *models.Store, input StoreInput) error {
+	if input.Kode != nil {
+		kode := strings.ToUpper(strings.TrimSpace(*input.Kode))
+		if kode == "" || len(kode) > 20 {
+			return fmt.Errorf("%w: kode required (max 20 characters)", errInvalidStoreInput)
+		}
+		store.Kode = kode
+	}
+	if input.Nama != nil {
+		nama := strings.TrimSpace(*input.Nama)
+		if nama == "" {
+			return fmt.Errorf("%w: nama required", errInvalidStoreInput)
+		}
+		store.Nama = nama
+	}
+	if input.Alamat != nil {
+		store.Alamat = strings.TrimSpace(*input.Alamat)
+	}
+	if input.Active != nil {
+		store.Active = *input.Active
+	}
+	return nil
+}
+
+// storeScope filter query ke toko aktif request (X-Store-ID / toko user). Tanpa toko aktif
+// (user kantor pusat tanpa X-Store-ID) semua toko ikut, buat laporan konsolidasi.
+func storeScope(c *gin.Context, column string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if storeID := auth.CurrentStoreID(c); storeID != nil {
+			return db.Where(column+" = ?", *storeID)
+		}
+		return db
+	}
+}
+
+// adminHomeStore toko asal admin yang login; nil = admin kantor pusat (boleh kelola semua toko)
+func adminHomeStore(c *gin.Context) *uint {
+	if claims, ok := auth.CurrentUser(c); ok {
+		return claims.StoreID
+	}
+	return nil
+}
+
+// homeStoreScope batasi users & API keys ke toko admin cabang. Beda dengan storeScope,
+// X-Store-ID diabaikan supaya admin kantor pusat tetap melihat akun kantor pusat.
+func homeStoreScope(c *gin.Context) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if storeID := adminHomeStore(c); storeID != nil {
+			return db.Where("store_id = ?", *storeID)
+		}
+		return db
+	}
+}
+
+// requireStore toko tujuan buat data baru: user kantor pusat wajib pilih toko lewat X-Store-ID,
+// toko nonaktif ditolak. Kalau gagal, response error sudah ditulis & return false.
+func requireStore(c *gin.Context, db *gorm.DB) (uint, bool) {
+	storeID := auth.CurrentStoreID(c)
+	if storeID == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": auth.StoreHeader + " header required to choose the store"})
+		return 0, false
+	}
+	var store models.Store
+	if err := db.First(&store, *storeID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Store not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return 0, false
+	}
+	if !store.Active {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Store %s is not active", store.Kode)})
+		return 0, false
+	}
+	return store.ID, true
+}
+
+var errStoreNotFound = errors.New("store not found")
+
+// resolveHomeStore validasi toko asal user / API key: 0 = kantor pusat (semua toko), selain itu toko harus ada
+func resolveHomeStore(db *gorm.DB, storeID uint) (*uint, error) {
+	if storeID == 0 {
+		return nil, nil
+	}
+	var store models.Store
+	if err := db.First(&store, storeID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %d", errStoreNotFound, storeID)
+		}
+		return nil, err
+	}
+	return &store.ID, nil
+}
+
+// GetAll godoc
+// @Summary Get all stores
+// @Description Retrieve all stores (branches), optionally only active ones
+// @Tags stores
+// @Produce json
+// @Param active query bool false "Filter by active flag"
+// @Success 200 {array} models.Store "List of stores"
+// @Failure 400 {object} map[string]string "Invalid filter"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /stores [get]
+func (ctrl *StoreController) GetAll(c *gin.Context) {
+	query := ctrl.DB.Model(&models.Store{})
+	if activeStr := c.Query("active"); activeStr != "" {
+		active, err := strconv.ParseBool(activeStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid active (must be true or false)"})
+			return
+		}
+		query = query.Where("active = ?", active)
+	}
+	var stores []models.Store
+	if err := query.Order("id").Find(&stores).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, stores)
+}
+
+// GetByID godoc
+// @Summary Get store by ID
+// @Description Retrieve a specific store by ID
+// @Tags stores
+// @Produce json
+// @Param id path int true "Store ID"
+// @Success 200 {object} models.Store "Store details"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 404 {object} map[string]string "Store not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /stores/{id} [get]
+func (ctrl *StoreController) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var store models.Store
+	if err := ctrl.DB.First(&store, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Store not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, store)
+}
+
+// Create godoc
+// @Summary Create a store
+// @Description Add a store/branch (kode & nama required)
+// @Tags stores
+// @Accept json
+// @Produce json
+// @Param store body StoreInput true "Store data"
+// @Success 201 {object} models.Store "Created store"
+// @Failure 400 {object} map[string]string "Validation error"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin role)"
+// @Failure 409 {object} map[string]string "Store kode already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /stores [post]
+func (ctrl *StoreController) Create(c *gin.Context) {
+	var input StoreInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Kode == nil || input.Nama == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Kode & nama required"})
+		return
+	}
+	store := models.Store{Active: true}
+	if err := applyStoreInput(&store, input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := ctrl.DB.Create(&store).Error; err != nil {
+		if isUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Store kode already exists"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, store)
+}
+
+// Update godoc
+// @Summary Update a store
+// @Description Update a store by ID (only provided fields are changed). Set active=false to close a branch; its data stays available for reports.
+// @Tags stores
+// @Accept json
+// @Produce json
+// @Param id path int true "Store ID"
+// @Param store body StoreInput true "Fields to update"
+// @Success 200 {object} models.Store "Updated store"
+// @Failure 400 {object} map[string]string "Invalid ID or validation error"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin role)"
+// @Failure 404 {object} map[string]string "Store not found"
+// @Failure 409 {object} map[string]string "Store kode already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /stores/{id} [put]
+func (ctrl *StoreController) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var store models.Store
+	if err := ctrl.DB.First(&store, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Store not found"})
+		return
+	}
+	var input StoreInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := applyStoreInput(&store, input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := ctrl.DB.Save(&store).Error; err != nil {
+		if isUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Store kode already exists"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, store)
+}
@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend-penjualan/auth"
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serveAs jalankan handler lewat auth.Middleware + auth.StoreContext sebagai user role di toko storeID (nil = kantor pusat)
+func serveAs(t *testing.T, fake *fakeDB, role string, storeID *uint, storeHeader string, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	cfg := auth.Config{Secret: []byte(strings.Repeat("s", 32)), Issuer: "test", AccessTTL: time.Minute}
+	token, _, err := cfg.IssueAccessToken(models.User{ID: 1, Username: role, Role: role, StoreID: storeID})
+	if err != nil {
+		t.Fatalf("IssueAccessToken() error = %v", err)
+	}
+	r := gin.New()
+	r.GET("/test", auth.Middleware(cfg, fake.db), auth.StoreContext(fake.db), handler)
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	if storeHeader != "" {
+		req.Header.Set(auth.StoreHeader, storeHeader)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestStoreScopes(t *testing.T) {
+	branch := uint(2)
+	tests := []struct {
+		name      string
+		storeID   *uint
+		header    string
+		wantStore bool // storeScope filter store_id = 2
+		wantHome  bool // homeStoreScope filter store_id = 2
+	}{
+		{"branch user", &branch, "", true, true},
+		{"head office", nil, "", false, false},
+		// X-Store-ID milih toko data, tapi akun kantor pusat tetap kelihatan (homeStoreScope abaikan header)
+		{"head office with X-Store-ID", nil, "2", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeDB(t, func(sql string) fakeResult {
+				if strings.Contains(sql, `FROM "stores"`) {
+					return fakeResult{columns: []string{"id"}, rows: [][]driver.Value{{int64(2)}}}
+				}
+				return fakeResult{columns: []string{"id"}}
+			})
+			w := serveAs(t, fake, models.RoleAdmin, tt.storeID, tt.header, func(c *gin.Context) {
+				fake.db.Scopes(storeScope(c, "store_id")).Find(&[]models.Product{})
+				fake.db.Scopes(homeStoreScope(c)).Find(&[]models.User{})
+			})
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d (%s)", w.Code, w.Body)
+			}
+			if got := len(fake.Matching(`FROM "products"`, "store_id = 2")) == 1; got != tt.wantStore {
+				t.Errorf("storeScope filtered = %v, want %v (%q)", got, tt.wantStore, fake.Statements())
+			}
+			if got := len(fake.Matching(`FROM "users"`, "store_id = 2")) == 1; got != tt.wantHome {
+				t.Errorf("homeStoreScope filtered = %v, want %v (%q)", got, tt.wantHome, fake.Statements())
+			}
+		})
+	}
+}
+
+func TestCheckAdminGrant(t *testing.T) {
+	branch, other := uint(2), uint(3)
+	tests := []struct {
+		name        string
+		adminStore  *uint
+		target      *uint
+		grantsAdmin bool
+		want        bool
+	}{
+		{"head office manages any store", nil, &other, true, true},
+		{"head office manages head office accounts", nil, nil, true, true},
+		{"branch admin manages own store", &branch, &branch, false, true},
+		{"branch admin cannot manage another store", &branch, &other, false, false},
+		{"branch admin cannot manage head office accounts", &branch, nil, false, false},
+		{"branch admin cannot grant admin", &branch, &branch, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeDB(t, func(string) fakeResult { return fakeResult{} })
+			var got bool
+			w := serveAs(t, fake, models.RoleAdmin, tt.adminStore, "", func(c *gin.Context) {
+				if got = checkAdminGrant(c, tt.target, tt.grantsAdmin); got {
+					c.Status(http.StatusNoContent)
+				}
+			})
+			if got != tt.want {
+				t.Fatalf("checkAdminGrant() = %v, want %v", got, tt.want)
+			}
+			if !got && w.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+			}
+		})
+	}
+}
+
+func TestRequireStore(t *testing.T) {
+	branch := uint(2)
+	tests := []struct {
+		name       string
+		storeID    *uint
+		active     bool
+		wantStatus int
+	}{
+		{"branch store", &branch, true, http.StatusNoContent},
+		{"head office must pick a store", nil, true, http.StatusBadRequest},
+		{"inactive store", &branch, false, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeDB(t, func(string) fakeResult {
+				return fakeResult{
+					columns: []string{"id", "kode", "active"},
+					rows:    [][]driver.Value{{int64(2), "CBG2", tt.active}},
+				}
+			})
+			w := serveAs(t, fake, models.RoleCashier, tt.storeID, "", func(c *gin.Context) {
+				if storeID, ok := requireStore(c, fake.db); ok && storeID == 2 {
+					c.Status(http.StatusNoContent)
+				}
+			})
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body)
+			}
+		})
+	}
+}
@@ -4,13 +4,14 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
-	"time"
 
+	"backend-penjualan/audit"
 	"backend-penjualan/models"
 	"errors"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // TransactionController godoc
@@ -23,67 +24,170 @@ func NewTransactionController(db *gorm.DB) *TransactionController {
 	return &TransactionController{DB: db}
 }
 
+var errTransactionNotInTrash = errors.New("transaction not found in trash")
+
+// transactionSortFields whitelist field yang boleh dipakai di ?sort=
+var transactionSortFields = map[string]string{
+	"id":           "transactions.id",
+	"nama_pembeli": "transactions.nama_pembeli",
+	"product_id":   "transactions.product_id",
+	"quantity":     "transactions.quantity",
+	"harga":        "transactions.harga",
+	"total":        "transactions.total",
+	"created_at":   "transactions.created_at",
+	"updated_at":   "transactions.updated_at",
+	"deleted_at":   "transactions.deleted_at",
+}
+
+// applyTransactionFilters pasang semua filter opsional GET /transactions (bisa dikombinasi),
+// plus scope toko aktif (X-Store-ID / toko user)
+func applyTransactionFilters(c *gin.Context, query *gorm.DB) (*gorm.DB, error) {
+	query = query.Scopes(storeScope(c, "transactions.store_id"))
+
+	// Optional filter: product_id (satu atau banyak)
+	productIDs, err := parseIDList(c, "product_id")
+	if err != nil {
+		return nil, err
+	}
+	if len(productIDs) > 0 {
+		query = query.Where("transactions.product_id IN ?", productIDs)
+	}
+
+	// Optional filter: rentang tanggal (YYYY-MM-DD, Asia/Jakarta) di created_at atau updated_at
+	dateColumn := "transactions.created_at"
+	switch c.DefaultQuery("date_field", "created_at") {
+	case "created_at":
+	case "updated_at":
+		dateColumn = "transactions.updated_at"
+	default:
+		return nil, fmt.Errorf("Invalid date_field (use created_at or updated_at)")
+	}
+	dateRange, err := parseDateRange(c)
+	if err != nil {
+		return nil, err
+	}
+	if dateRange.Start != nil {
+		query = query.Where(dateColumn+" >= ?", *dateRange.Start)
+	}
+	if dateRange.End != nil {
+		query = query.Where(dateColumn+" < ?", *dateRange.End)
+	}
+
+	// Optional filter: range total & quantity
+	minTotal, maxTotal, err := parseFloatRange(c, "total")
+	if err != nil {
+		return nil, err
+	}
+	if minTotal != nil {
+		query = query.Where("transactions.total >= ?", *minTotal)
+	}
+	if maxTotal != nil {
+		query = query.Where("transactions.total <= ?", *maxTotal)
+	}
+	minQuantity, maxQuantity, err := parseFloatRange(c, "quantity")
+	if err != nil {
+		return nil, err
+	}
+	if minQuantity != nil {
+		query = query.Where("transactions.quantity >= ?", *minQuantity)
+	}
+	if maxQuantity != nil {
+		query = query.Where("transactions.quantity <= ?", *maxQuantity)
+	}
+
+	// Optional filter: search by nama_pembeli or product.nama (partial, case-insensitive)
+	if search := c.Query("search"); search != "" {
+		// JOIN untuk filter on nama_pembeli or products.nama
+		query = query.Joins("JOIN products ON products.id = transactions.product_id").
+			Where("transactions.nama_pembeli ILIKE ? OR products.nama ILIKE ?", "%"+search+"%", "%"+search+"%")
+	}
+	return query, nil
+}
+
 // GetAll godoc
 // @Summary Get all transactions
-// @Description Retrieve list of all sales transactions with optional filters (product_id, start_date, search by buyer or product name)
+// @Description Retrieve paginated list of sales transactions of the active store (X-Store-ID or the user's store; head office without X-Store-ID sees all stores) with combinable filters (product_id list, date range on created_at/updated_at, total & quantity ranges, search by buyer or product name). Dates use Asia/Jakarta. Total rows returned in X-Total-Count header.
 // @Tags transactions
 // @Accept json
 // @Produce json
-// @Param product_id query int false "Filter by product ID"
-// @Param start_date query string false "Filter by start date (YYYY-MM-DD)"
+// @Param product_id query []int false "Filter by product ID (repeat or comma separated for multiple)" collectionFormat(multi)
+// @Param start_date query string false "Filter by start date, inclusive (YYYY-MM-DD)"
+// @Param end_date query string false "Filter by end date, inclusive (YYYY-MM-DD)"
+// @Param date_field query string false "Date column used by start_date/end_date: created_at (default) or updated_at" Enums(created_at, updated_at)
+// @Param min_total query number false "Minimum total"
+// @Param max_total query number false "Maximum total"
+// @Param min_quantity query int false "Minimum quantity"
+// @Param max_quantity query int false "Maximum quantity"
 // @Param search query string false "Search by buyer name or product name (partial)"
+// @Param include_deleted query bool false "Also return transactions in the trash"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (max 500; default 50 when page is set, all rows when neither page nor limit is set)"
+// @Param sort query string false "Sort fields, comma separated, prefix - for descending (id, nama_pembeli, product_id, quantity, harga, total, created_at, updated_at, deleted_at)"
 // @Success 200 {array} models.Transaction "List of transactions (with preloaded Product)"
-// @Failure 400 {object} map[string]string "Invalid filter format"
+// @Header 200 {integer} X-Total-Count "Total transactions matching the filters"
+// @Failure 400 {object} map[string]string "Invalid filter, pagination or sort format"
 // @Failure 500 {object} map[string]string "Query failed"
 // @Router /transactions [get]
 func (ctrl *TransactionController) GetAll(c *gin.Context) {
 	var transactions []models.Transaction
 
-	// Base query dengan preload Product
-	query := ctrl.DB.Preload("Product")
+	pagination, err := parseLegacyPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sortClause, err := parseSort(c, transactionSortFields, "transactions.id ASC")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	// Optional filter: product_id (exact)
-	if productIDStr := c.Query("product_id"); productIDStr != "" {
-		if productID, err := strconv.Atoi(productIDStr); err == nil {
-			query = query.Where("product_id = ?", productID)
-		} else {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product_id format (must be number)"})
-			return
-		}
+	includeDeleted, err := parseIncludeDeleted(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	// Optional filter: start_date (YYYY-MM-DD)
-	if startDate := c.Query("start_date"); startDate != "" {
-		parsedDate, err := time.Parse("2006-01-02", startDate)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_date format. Use YYYY-MM-DD"})
-			return
-		}
-		query = query.Where("created_at >= ?", parsedDate)
+	// Base query (preload Product belakangan, setelah count)
+	query := ctrl.DB.Model(&models.Transaction{})
+	if includeDeleted {
+		query = query.Unscoped()
 	}
 
-	// Optional filter: search by nama_pembeli or product.nama (partial, case-insensitive)
-	if search := c.Query("search"); search != "" {
-		// JOIN untuk filter on nama_pembeli or products.nama
-		query = query.Joins("JOIN products ON products.id = transactions.product_id").
-			Where("transactions.nama_pembeli ILIKE ? OR products.nama ILIKE ?", "%"+search+"%", "%"+search+"%")
+	query, err = applyTransactionFilters(c, query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
+	query = query.Session(&gorm.Session{})
 
-	if err := query.Find(&transactions).Error; err != nil {
+	// Total sebelum limit (buat X-Total-Count)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
 		return
 	}
 
+	if err := query.Preload("Product", withDeleted).Preload("Customer", withDeleted).
+		Order(sortClause).Order("transactions.id").
+		Offset(pagination.Offset()).Limit(pagination.Limit).
+		Find(&transactions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		return
+	}
+
+	setPaginationHeaders(c, pagination, total)
 	c.JSON(http.StatusOK, transactions)
 }
 
 // GetByID godoc
 // @Summary Get transaction by ID
-// @Description Retrieve a specific transaction by ID with preloaded Product
+// @Description Retrieve a specific transaction by ID with preloaded Product (transactions in the trash only with include_deleted=true)
 // @Tags transactions
 // @Accept json
 // @Produce json
 // @Param id path int true "Transaction ID"
+// @Param include_deleted query bool false "Also find transactions in the trash"
 // @Success 200 {object} models.Transaction "Transaction details (with Product)"
 // @Failure 400 {object} map[string]string "Invalid ID"
 // @Failure 404 {object} map[string]string "Transaction not found"
@@ -96,8 +200,18 @@ func (ctrl *TransactionController) GetByID(c *gin.Context) {
 		return
 	}
 
+	includeDeleted, err := parseIncludeDeleted(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	query := ctrl.DB.Scopes(storeScope(c, "store_id"))
+	if includeDeleted {
+		query = query.Unscoped()
+	}
+
 	var transaction models.Transaction
-	if err := ctrl.DB.Preload("Product").First(&transaction, id).Error; err != nil {
+	if err := query.Preload("Product", withDeleted).Preload("Customer", withDeleted).First(&transaction, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
 		} else {
@@ -111,18 +225,20 @@ func (ctrl *TransactionController) GetByID(c *gin.Context) {
 
 // Create godoc
 // @Summary Create a new transaction
-// @Description Create a new sales transaction (fetch price from product, compute total)
+// @Description Create a new sales transaction in the active store (fetch price from product, compute total, decrement product stock). The product must belong to the store; head office must send X-Store-ID.
 // @Tags transactions
 // @Accept json
 // @Produce json
-// @Param input body CreateTransactionInput true "Transaction input (nama_pembeli, product_id, quantity)"
+// @Param input body CreateTransactionInput true "Transaction input (nama_pembeli and/or customer_id, product_id, quantity)"
 // @Success 201 {object} models.Transaction "Created transaction (with Product)"
 // @Failure 400 {object} map[string]string "Validation error"
-// @Failure 404 {object} map[string]string "Product not found"
+// @Failure 404 {object} map[string]string "Product or customer not found"
+// @Failure 409 {object} map[string]interface{} "Insufficient stock"
 // @Failure 500 {object} map[string]string "Create failed"
 // @Router /transactions [post]
 type CreateTransactionInput struct {
 	NamaPembeli string `json:"nama_pembeli"`
+	CustomerID  *int64 `json:"customer_id"` // Optional, nama_pembeli diambil dari customer kalau kosong
 	ProductID   int64  `json:"product_id"`
 	Quantity    int64  `json:"quantity"`
 }
@@ -135,10 +251,6 @@ func (ctrl *TransactionController) Create(c *gin.Context) {
 	}
 
 	// Validasi manual
-	if input.NamaPembeli == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Nama pembeli required"})
-		return
-	}
 	if input.ProductID <= 0 {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Product ID required & positive"})
 		return
@@ -148,32 +260,52 @@ func (ctrl *TransactionController) Create(c *gin.Context) {
 		return
 	}
 
-	// Fetch product (cast int64 ke uint)
-	var product models.Product
-	if err := ctrl.DB.First(&product, uint(input.ProductID)).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
-		} else {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Product query failed: %v", err.Error())})
-		}
+	// Customer terdaftar (customer_id) atau pembeli walk-in (nama_pembeli saja)
+	customerID, namaPembeli, err := resolveCustomer(ctrl.DB, input.CustomerID, input.NamaPembeli)
+	if err != nil {
+		respondCustomerError(c, err)
 		return
 	}
-
-	// Create transaction (cast ke uint)
-	transaction := models.Transaction{
-		NamaPembeli: input.NamaPembeli,
-		ProductID:   uint(input.ProductID),
-		Quantity:    uint(input.Quantity),
-		Harga:       product.Harga,
-		Total:       float64(input.Quantity) * product.Harga,
+	storeID, ok := requireStore(c, ctrl.DB)
+	if !ok {
+		return
 	}
-	if err := ctrl.DB.Create(&transaction).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Create failed: %v", err.Error())})
+
+	// Kurangi stok & create transaction dalam satu DB transaction (row lock biar gak oversell)
+	var transaction models.Transaction
+	err = ctrl.DB.Transaction(func(tx *gorm.DB) error {
+		// Produk toko lain dianggap gak ada
+		product, err := reserveStock(tx, storeID, uint(input.ProductID), int(input.Quantity))
+		if err != nil {
+			return err
+		}
+		transaction = models.Transaction{
+			StoreID:     storeID,
+			NamaPembeli: namaPembeli,
+			CustomerID:  customerID,
+			ProductID:   uint(input.ProductID),
+			Quantity:    uint(input.Quantity),
+			Harga:       product.Harga,
+			Total:       float64(input.Quantity) * product.Harga,
+		}
+		if err := tx.Create(&transaction).Error; err != nil {
+			return err
+		}
+		return audit.Record(tx, c, audit.Entry{
+			Action:   models.AuditActionCreate,
+			Entity:   audit.EntityTransaction,
+			EntityID: transaction.ID,
+			StoreID:  &transaction.StoreID,
+			After:    transaction,
+		})
+	})
+	if err != nil {
+		respondStockError(c, err, "Create")
 		return
 	}
 
 	// Preload & response
-	if err := ctrl.DB.Preload("Product").First(&transaction, transaction.ID).Error; err != nil {
+	if err := ctrl.DB.Preload("Product", withDeleted).Preload("Customer", withDeleted).First(&transaction, transaction.ID).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Preload failed: %v", err.Error())})
 		return
 	}
@@ -182,7 +314,7 @@ func (ctrl *TransactionController) Create(c *gin.Context) {
 
 // Update godoc
 // @Summary Update a transaction partially
-// @Description Update partial fields (nama_pembeli or quantity), recompute total from product price
+// @Description Update partial fields (nama_pembeli, customer_id or quantity), recompute total from product price and adjust product stock
 // @Tags transactions
 // @Accept json
 // @Produce json
@@ -191,10 +323,13 @@ func (ctrl *TransactionController) Create(c *gin.Context) {
 // @Success 200 {object} models.Transaction "Updated transaction (with Product)"
 // @Failure 400 {object} map[string]string "Validation error or no changes"
 // @Failure 404 {object} map[string]string "Transaction not found"
+// @Failure 409 {object} map[string]interface{} "Insufficient stock for the new quantity"
 // @Failure 500 {object} map[string]string "Update failed"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin or manager role)"
 // @Router /transactions/{id} [patch]
 type UpdateTransactionInput struct {
 	NamaPembeli *string `json:"nama_pembeli"`
+	CustomerID  *int64  `json:"customer_id"`
 	Quantity    *uint   `json:"quantity"`
 }
 
@@ -206,7 +341,7 @@ func (ctrl *TransactionController) Update(c *gin.Context) {
 	}
 
 	var transaction models.Transaction
-	if err := ctrl.DB.First(&transaction, id).Error; err != nil {
+	if err := ctrl.DB.Scopes(storeScope(c, "store_id")).First(&transaction, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
 		} else {
@@ -231,6 +366,19 @@ func (ctrl *TransactionController) Update(c *gin.Context) {
 		}
 		updates["nama_pembeli"] = *input.NamaPembeli
 	}
+	if input.CustomerID != nil {
+		nama := ""
+		if input.NamaPembeli != nil {
+			nama = *input.NamaPembeli
+		}
+		customerID, namaPembeli, err := resolveCustomer(ctrl.DB, input.CustomerID, nama)
+		if err != nil {
+			respondCustomerError(c, err)
+			return
+		}
+		updates["customer_id"] = *customerID
+		updates["nama_pembeli"] = namaPembeli
+	}
 	if input.Quantity != nil {
 		if *input.Quantity == 0 { // uint, jadi ==0 invalid
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Quantity must be at least 1"})
@@ -245,36 +393,67 @@ func (ctrl *TransactionController) Update(c *gin.Context) {
 		return
 	}
 
-	// Update fields partial
-	if err := ctrl.DB.Model(&transaction).Updates(updates).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Update failed: %v", err.Error())})
-		return
-	}
+	// Update fields partial + sesuaikan stok kalau quantity berubah
+	err = ctrl.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock row transaction biar quantity lama gak berubah di tengah jalan
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&transaction, id).Error; err != nil {
+			return err
+		}
+		before := transaction
+		if input.Quantity != nil {
+			diff := int(*input.Quantity) - int(transaction.Quantity)
+			if diff > 0 {
+				if _, err := reserveStock(tx, transaction.StoreID, transaction.ProductID, diff); err != nil {
+					return err
+				}
+			} else if diff < 0 {
+				if err := releaseStock(tx, transaction.ProductID, -diff); err != nil {
+					return err
+				}
+			}
+		}
+		if err := tx.Model(&transaction).Updates(updates).Error; err != nil {
+			return err
+		}
 
-	// Recompute harga/total: refresh transaction dulu biar Quantity up-to-date
-	if err := ctrl.DB.Preload("Product").First(&transaction, id).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh transaction"})
+		// Recompute harga/total: refresh transaction dulu biar Quantity up-to-date
+		if err := tx.Preload("Product", withDeleted).First(&transaction, id).Error; err != nil {
+			return err
+		}
+		// Update harga & total berdasarkan product terbaru (ikut satu DB transaction biar audit log-nya lengkap)
+		newHarga := transaction.Product.Harga // Dari preload, lebih aman
+		newTotal := float64(transaction.Quantity) * newHarga
+		if err := tx.Model(&transaction).Updates(map[string]interface{}{
+			"harga": newHarga,
+			"total": newTotal,
+		}).Error; err != nil {
+			return err
+		}
+		return audit.Record(tx, c, audit.Entry{
+			Action:   models.AuditActionUpdate,
+			Entity:   audit.EntityTransaction,
+			EntityID: transaction.ID,
+			StoreID:  &transaction.StoreID,
+			Before:   before,
+			After:    transaction,
+		})
+	})
+	if err != nil {
+		respondStockError(c, err, "Update")
 		return
 	}
 
-	// Update harga & total berdasarkan product terbaru
-	newHarga := transaction.Product.Harga // Dari preload, lebih aman
-	newTotal := float64(transaction.Quantity) * newHarga
-	if err := ctrl.DB.Model(&transaction).Updates(map[string]interface{}{
-		"harga": newHarga,
-		"total": newTotal,
-	}).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Recompute failed: %v", err.Error())})
+	// Response full dengan Product & Customer
+	if err := ctrl.DB.Preload("Product", withDeleted).Preload("Customer", withDeleted).First(&transaction, id).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh transaction"})
 		return
 	}
-
-	// Response full (udah preloaded)
 	c.JSON(http.StatusOK, transaction)
 }
 
 // Delete godoc
 // @Summary Delete a transaction
-// @Description Soft delete a transaction by ID (or hard delete if Unscoped)
+// @Description Soft delete a transaction by ID: it moves to the trash (GET /transactions/trash), is excluded from reports & forecasts and its quantity is returned to the product stock
 // @Tags transactions
 // @Accept json
 // @Produce json
@@ -283,6 +462,7 @@ func (ctrl *TransactionController) Update(c *gin.Context) {
 // @Failure 400 {object} map[string]string "Invalid ID"
 // @Failure 404 {object} map[string]string "Transaction not found"
 // @Failure 500 {object} map[string]string "Delete failed"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin or manager role)"
 // @Router /transactions/{id} [delete]
 func (ctrl *TransactionController) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -291,8 +471,34 @@ func (ctrl *TransactionController) Delete(c *gin.Context) {
 		return
 	}
 
-	// Soft delete: set deleted_at; kalo mau hard, ganti ke ctrl.DB.Delete(&models.Transaction{}, id)
-	if err := ctrl.DB.Unscoped().Delete(&models.Transaction{}, id).Error; err != nil {
+	// Hapus transaction & kembalikan stok produk dalam satu DB transaction
+	err = ctrl.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock row biar dua DELETE bersamaan gak sama-sama mengembalikan stok
+		var transaction models.Transaction
+		if err := tx.Scopes(storeScope(c, "store_id")).Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&transaction, id).Error; err != nil {
+			return err
+		}
+		// Soft delete: set deleted_at (hapus permanen lewat DELETE /transactions/trash)
+		result := tx.Delete(&transaction)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound // Sudah dihapus request lain
+		}
+		if err := releaseStock(tx, transaction.ProductID, int(transaction.Quantity)); err != nil {
+			return err
+		}
+		return audit.Record(tx, c, audit.Entry{
+			Action:   models.AuditActionDelete,
+			Entity:   audit.EntityTransaction,
+			EntityID: transaction.ID,
+			StoreID:  &transaction.StoreID,
+			Before:   transaction,
+		})
+	})
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
 		} else {
@@ -302,4 +508,173 @@ func (ctrl *TransactionController) Delete(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Transaction deleted successfully"})
-}
\ No newline at end of file
+}
+
+// Trash godoc
+// @Summary List deleted transactions
+// @Description Retrieve paginated transactions in the trash of the active store, newest deleted first. Accepts the same filters as GET /transactions. Total rows returned in X-Total-Count header.
+// @Tags transactions
+// @Produce json
+// @Param product_id query []int false "Filter by product ID (repeat or comma separated for multiple)" collectionFormat(multi)
+// @Param start_date query string false "Filter by start date, inclusive (YYYY-MM-DD)"
+// @Param end_date query string false "Filter by end date, inclusive (YYYY-MM-DD)"
+// @Param search query string false "Search by buyer name or product name (partial)"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (default 50, max 500)"
+// @Param sort query string false "Sort fields, comma separated, prefix - for descending (id, nama_pembeli, product_id, quantity, harga, total, created_at, updated_at, deleted_at)"
+// @Success 200 {array} models.Transaction "List of deleted transactions (with preloaded Product)"
+// @Header 200 {integer} X-Total-Count "Total transactions in the trash"
+// @Failure 400 {object} map[string]string "Invalid filter, pagination or sort format"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin or manager role)"
+// @Failure 500 {object} map[string]string "Query failed"
+// @Router /transactions/trash [get]
+func (ctrl *TransactionController) Trash(c *gin.Context) {
+	pagination, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sortClause, err := parseSort(c, transactionSortFields, "transactions.deleted_at DESC")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query, err := applyTransactionFilters(c, ctrl.DB.Model(&models.Transaction{}).Scopes(onlyDeleted("transactions")))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		return
+	}
+	var transactions []models.Transaction
+	if err := query.Preload("Product", withDeleted).Preload("Customer", withDeleted).
+		Order(sortClause).Order("transactions.id").
+		Offset(pagination.Offset()).Limit(pagination.Limit).
+		Find(&transactions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Query failed: %v", err.Error())})
+		return
+	}
+	setPaginationHeaders(c, pagination, total)
+	c.JSON(http.StatusOK, transactions)
+}
+
+// Restore godoc
+// @Summary Restore a deleted transaction
+// @Description Move a transaction out of the trash and take its quantity from the product stock again. Fails with 409 if the stock is no longer sufficient, or 404 if the product itself is in the trash.
+// @Tags transactions
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Success 200 {object} models.Transaction "Restored transaction (with Product)"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin or manager role)"
+// @Failure 404 {object} map[string]string "Transaction not found in trash or product deleted"
+// @Failure 409 {object} map[string]interface{} "Insufficient stock"
+// @Failure 500 {object} map[string]string "Restore failed"
+// @Router /transactions/{id}/restore [post]
+func (ctrl *TransactionController) Restore(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var transaction models.Transaction
+	err = ctrl.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Scopes(storeScope(c, "store_id"), onlyDeleted("transactions")).
+			Clauses(clause.Locking{Strength: "UPDATE"}).First(&transaction, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errTransactionNotInTrash
+			}
+			return err
+		}
+		before := transaction
+		// Stok sudah dikembalikan waktu dihapus, jadi dipesan ulang (produk di trash gak bisa dijual)
+		if _, err := reserveStock(tx, transaction.StoreID, transaction.ProductID, int(transaction.Quantity)); err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&transaction).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		transaction.DeletedAt = gorm.DeletedAt{}
+		return audit.Record(tx, c, audit.Entry{
+			Action:   models.AuditActionRestore,
+			Entity:   audit.EntityTransaction,
+			EntityID: transaction.ID,
+			StoreID:  &transaction.StoreID,
+			Before:   before,
+			After:    transaction,
+		})
+	})
+	if errors.Is(err, errTransactionNotInTrash) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found in trash"})
+		return
+	}
+	if err != nil {
+		respondStockError(c, err, "Restore")
+		return
+	}
+
+	if err := ctrl.DB.Preload("Product", withDeleted).Preload("Customer", withDeleted).First(&transaction, id).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh transaction"})
+		return
+	}
+	c.JSON(http.StatusOK, transaction)
+}
+
+// Purge godoc
+// @Summary Permanently delete transactions in the trash
+// @Description Permanently delete transactions in the trash of the active store (optionally only those deleted at least older_than_days ago). Stock is not changed; it was already returned when they were deleted.
+// @Tags transactions
+// @Produce json
+// @Param older_than_days query int false "Only purge transactions deleted at least this many days ago"
+// @Success 200 {object} map[string]int "Number of purged transactions"
+// @Failure 400 {object} map[string]string "Invalid older_than_days"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin role)"
+// @Failure 500 {object} map[string]string "Purge failed"
+// @Router /transactions/trash [delete]
+func (ctrl *TransactionController) Purge(c *gin.Context) {
+	cutoff, err := parsePurgeCutoff(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	purged := 0
+	err = ctrl.DB.Transaction(func(tx *gorm.DB) error {
+		query := tx.Scopes(storeScope(c, "store_id"), onlyDeleted("transactions"))
+		if cutoff != nil {
+			query = query.Where("deleted_at <= ?", *cutoff)
+		}
+		var transactions []models.Transaction
+		if err := query.Order("id").Find(&transactions).Error; err != nil {
+			return err
+		}
+		for _, transaction := range transactions {
+			if err := tx.Unscoped().Delete(&transaction).Error; err != nil {
+				return err
+			}
+			if err := audit.Record(tx, c, audit.Entry{
+				Action:   models.AuditActionPurge,
+				Entity:   audit.EntityTransaction,
+				EntityID: transaction.ID,
+				StoreID:  &transaction.StoreID,
+				Before:   transaction,
+			}); err != nil {
+				return err
+			}
+			purged++
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Purge failed: %v", err.Error())})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"purged": purged})
+}
@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// transactionRow satu row transactions (quantity 3 produk 7 toko 1); deletedAt nil = belum di trash
+func transactionRow(deletedAt *time.Time) fakeResult {
+	var deleted driver.Value
+	if deletedAt != nil {
+		deleted = *deletedAt
+	}
+	return fakeResult{
+		columns: []string{"id", "store_id", "product_id", "nama_pembeli", "quantity", "harga", "total", "deleted_at"},
+		rows:    [][]driver.Value{{int64(5), int64(1), int64(7), "Budi", int64(3), 15000.0, 45000.0, deleted}},
+	}
+}
+
+func transactionContext(method, path string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, nil)
+	c.Params = gin.Params{{Key: "id", Value: "5"}}
+	return c, w
+}
+
+func TestTransactionDeleteReleasesStock(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantStatus   int
+		wantRelease  bool
+	}{
+		{"moves to trash", 1, http.StatusOK, true},
+		// Request lain sudah menghapus duluan: stok jangan dikembalikan dua kali
+		{"already deleted", 0, http.StatusNotFound, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeDB(t, func(sql string) fakeResult {
+				switch {
+				case strings.Contains(sql, `FROM "transactions"`):
+					return transactionRow(nil)
+				case strings.HasPrefix(sql, `UPDATE "transactions"`):
+					return fakeResult{rowsAffected: tt.rowsAffected}
+				}
+				return fakeResult{columns: []string{"id"}, rowsAffected: 1}
+			})
+			c, w := transactionContext(http.MethodDelete, "/transactions/5")
+
+			NewTransactionController(fake.db).Delete(c)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if deletes := fake.Matching(`DELETE FROM "transactions"`); len(deletes) > 0 {
+				t.Errorf("transaction hard deleted: %q", deletes)
+			}
+			if updates := fake.Matching(`UPDATE "transactions" SET "deleted_at"=`); len(updates) != 1 {
+				t.Errorf("statements = %q, want one soft delete", fake.Statements())
+			}
+			released := len(fake.Matching(`UPDATE "products" SET "stok"=stok + 3`, "id = 7")) == 1
+			if released != tt.wantRelease {
+				t.Errorf("stock released = %v, want %v (%q)", released, tt.wantRelease, fake.Statements())
+			}
+			audited := len(fake.Matching(`INSERT INTO "audit_logs"`, `'delete'`)) == 1
+			if audited != tt.wantRelease {
+				t.Errorf("delete audited = %v, want %v", audited, tt.wantRelease)
+			}
+		})
+	}
+}
+
+func TestTransactionRestoreReservesStock(t *testing.T) {
+	deletedAt := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		stok        int
+		inTrash     bool
+		wantStatus  int
+		wantRestore bool
+	}{
+		{"restored", 5, true, http.StatusOK, true},
+		{"stock sold in the meantime", 2, true, http.StatusConflict, false},
+		{"not in trash", 5, false, http.StatusNotFound, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeDB(t, func(sql string) fakeResult {
+				switch {
+				case strings.Contains(sql, `FROM "transactions"`) && strings.Contains(sql, "deleted_at IS NOT NULL"):
+					if !tt.inTrash {
+						return fakeResult{columns: []string{"id"}}
+					}
+					return transactionRow(&deletedAt)
+				case strings.Contains(sql, `FROM "transactions"`):
+					return transactionRow(nil)
+				case strings.Contains(sql, `FROM "products"`):
+					return productRow(7, 1, "Kopi", 15000, tt.stok)
+				}
+				return fakeResult{columns: []string{"id"}, rowsAffected: 1}
+			})
+			c, w := transactionContext(http.MethodPost, "/transactions/5/restore")
+
+			NewTransactionController(fake.db).Restore(c)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.inTrash {
+				// Stok dipesan ulang dari toko transaksinya sendiri
+				if lookups := fake.Matching(`FROM "products"`, "store_id = 1", "FOR UPDATE"); len(lookups) != 1 {
+					t.Errorf("statements = %q, want a locked product lookup in store 1", fake.Statements())
+				}
+			}
+			reserved := len(fake.Matching(`UPDATE "products" SET "stok"=stok - 3`)) == 1
+			restored := len(fake.Matching(`UPDATE "transactions" SET "deleted_at"=NULL`)) == 1
+			audited := len(fake.Matching(`INSERT INTO "audit_logs"`, `'restore'`)) == 1
+			if reserved != tt.wantRestore || restored != tt.wantRestore || audited != tt.wantRestore {
+				t.Errorf("reserved = %v restored = %v audited = %v, want %v (%q)", reserved, restored, audited, tt.wantRestore, fake.Statements())
+			}
+		})
+	}
+}
+
+func TestTransactionPurgeKeepsStock(t *testing.T) {
+	deletedAt := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	fake := newFakeDB(t, func(sql string) fakeResult {
+		if strings.Contains(sql, `FROM "transactions"`) {
+			return transactionRow(&deletedAt)
+		}
+		return fakeResult{rowsAffected: 1}
+	})
+	c, w := transactionContext(http.MethodDelete, "/transactions/trash?older_than_days=30")
+
+	NewTransactionController(fake.db).Purge(c)
+
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"purged":1`) {
+		t.Fatalf("status = %d body = %s, want 200 purged 1", w.Code, w.Body)
+	}
+	if selects := fake.Matching(`FROM "transactions"`, "transactions.deleted_at IS NOT NULL", "deleted_at <= "); len(selects) != 1 {
+		t.Errorf("statements = %q, want trash lookup limited by the cutoff", fake.Statements())
+	}
+	if deletes := fake.Matching(`DELETE FROM "transactions"`, `"transactions"."id" = 5`); len(deletes) != 1 {
+		t.Errorf("statements = %q, want one permanent delete", fake.Statements())
+	}
+	// Stok sudah dikembalikan waktu transaksi masuk trash
+	if stock := fake.Matching(`UPDATE "products"`); len(stock) > 0 {
+		t.Errorf("stock changed on purge: %q", stock)
+	}
+}
+
+func TestTrashParams(t *testing.T) {
+	tests := []struct {
+		query       string
+		wantInclude bool
+		wantCutoff  bool
+		wantErr     bool
+	}{
+		{"", false, false, false},
+		{"include_deleted=true&older_than_days=0", true, true, false},
+		{"include_deleted=maybe", false, false, true},
+		{"older_than_days=-1", false, false, true},
+	}
+	for _, tt := range tests {
+		c, _ := queryContext(tt.query)
+		include, includeErr := parseIncludeDeleted(c)
+		cutoff, cutoffErr := parsePurgeCutoff(c)
+		if gotErr := includeErr != nil || cutoffErr != nil; gotErr != tt.wantErr {
+			t.Errorf("%q: errors = %v, %v, wantErr %v", tt.query, includeErr, cutoffErr, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if include != tt.wantInclude || (cutoff != nil) != tt.wantCutoff {
+			t.Errorf("%q: include = %v cutoff = %v, want %v and cutoff %v", tt.query, include, cutoff, tt.wantInclude, tt.wantCutoff)
+		}
+		if cutoff != nil && cutoff.After(time.Now()) {
+			t.Errorf("%q: cutoff %v is in the future", tt.query, cutoff)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// withDeleted dipakai di Preload relasi yang mungkin sudah masuk trash (mis. produk dari transaksi lama)
+func withDeleted(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+// onlyDeleted scope isi trash (data yang sudah di-soft delete)
+func onlyDeleted(table string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().Where(table + ".deleted_at IS NOT NULL")
+	}
+}
+
+// parseIncludeDeleted baca ?include_deleted=true (ikutkan data di trash)
+func parseIncludeDeleted(c *gin.Context) (bool, error) {
+	raw := c.Query("include_deleted")
+	if raw == "" {
+		return false, nil
+	}
+	include, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("Invalid include_deleted (must be true or false)")
+	}
+	return include, nil
+}
+
+// parsePurgeCutoff baca ?older_than_days=N: cuma data yang sudah di trash minimal N hari yang di-purge (kosong = semua)
+func parsePurgeCutoff(c *gin.Context) (*time.Time, error) {
+	raw := c.Query("older_than_days")
+	if raw == "" {
+		return nil, nil
+	}
+	days, err := strconv.Atoi(raw)
+	if err != nil || days < 0 {
+		return nil, fmt.Errorf("Invalid older_than_days (must be number >= 0)")
+	}
+	cutoff := time.Now().AddDate(0, 0, -days)
+	return &cutoff, nil
+}
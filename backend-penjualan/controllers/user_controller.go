@@ -0,0 +1,330 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"backend-penjualan/auth"
+	"backend-penjualan/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// UserController godoc
+// @Description User controller handles user accounts and roles (admin only)
+type UserController struct {
+	DB *gorm.DB
+}
+
+func NewUserController(db *gorm.DB) *UserController {
+	return &UserController{DB: db}
+}
+
+var errInvalidUserInput = errors.New("invalid user input")
+
+// userSortFields whitelist field yang boleh dipakai di ?sort=
+var userSortFields = map[string]string{
+	"id":            "id",
+	"username":      "username",
+	"nama":          "nama",
+	"role":          "role",
+	"last_login_at": "last_login_at",
+	"created_at":    "created_at",
+}
+
+// UserInput body buat create/update user (update: field null gak diubah)
+type UserInput struct {
+	Username *string `json:"username"` // Huruf kecil, unik (case-insensitive)
+	Nama     *string `json:"nama"`
+	Password *string `json:"password"` // Min. 8 karakter
+	Role     *string `json:"role"`     // admin / manager / cashier (default cashier)
+	Active   *bool   `json:"active"`   // false = gak bisa login, semua sesi di-revoke
+	StoreID  *uint   `json:"store_id"` // Toko asal; 0 = kantor pusat (akses semua toko)
+}
+
+// applyUserInput validasi & copy field input ke user
+func applyUserInput(db *gorm.DB, user *models.User, input UserInput) error {
+	if input.Username != nil {
+		username := strings.ToLower(strings.TrimSpace(*input.Username))
+		if username == "" || len(username) > 50 {
+			return fmt.Errorf("%w: username required (max 50 characters)", errInvalidUserInput)
+		}
+		user.Username = username
+	}
+	if input.Nama != nil {
+		user.Nama = strings.TrimSpace(*input.Nama)
+	}
+	if input.Password != nil {
+		hash, err := auth.HashPassword(*input.Password)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errInvalidUserInput, err)
+		}
+		user.PasswordHash = hash
+	}
+	if input.Role != nil {
+		role := strings.ToLower(strings.TrimSpace(*input.Role))
+		if role != models.RoleAdmin && role != models.RoleManager && role != models.RoleCashier {
+			return fmt.Errorf("%w: role must be admin, manager or cashier", errInvalidUserInput)
+		}
+		user.Role = role
+	}
+	if input.Active != nil {
+		user.Active = *input.Active
+	}
+	if input.StoreID != nil {
+		storeID, err := resolveHomeStore(db, *input.StoreID)
+		if errors.Is(err, errStoreNotFound) {
+			return fmt.Errorf("%w: %v", errInvalidUserInput, err)
+		} else if err != nil {
+			return err
+		}
+		user.StoreID = storeID
+	}
+	return nil
+}
+
+// writeUserInputError 400 buat input gak valid, 500 buat error database
+func writeUserInputError(c *gin.Context, err error) {
+	if errors.Is(err, errInvalidUserInput) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// checkAdminGrant admin cabang cuma boleh kelola akun di tokonya sendiri & gak boleh kasih role admin
+// (toko lain, kantor pusat atau admin baru = eskalasi hak akses). Kalau ditolak, response 403 sudah ditulis.
+func checkAdminGrant(c *gin.Context, storeID *uint, grantsAdmin bool) bool {
+	homeStore := adminHomeStore(c)
+	if homeStore == nil {
+		return true
+	}
+	if !sameStore(storeID, homeStore) {
+		c.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("Branch admins can only manage accounts of their own store (store_id %d)", *homeStore)})
+		return false
+	}
+	if grantsAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Branch admins cannot grant the admin role"})
+		return false
+	}
+	return true
+}
+
+// sameStore bandingkan store_id nullable
+func sameStore(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// GetAll godoc
+// @Summary Get all users
+// @Description Retrieve paginated users, optionally filtered by role, active flag or username/nama search. Branch admins only see users of their own store. Total rows returned in X-Total-Count header.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param role query string false "Filter by role" Enums(admin, manager, cashier)
+// @Param active query bool false "Filter by active flag"
+// @Param search query string false "Search username or nama (case-insensitive)"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (default 50, max 500)"
+// @Param sort query string false "Sort fields, comma separated, prefix - for descending (id, username, nama, role, last_login_at, created_at)"
+// @Success 200 {array} models.User "List of users"
+// @Header 200 {integer} X-Total-Count "Total users matching the filter"
+// @Failure 400 {object} map[string]string "Invalid filter, pagination or sort"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin role)"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users [get]
+func (ctrl *UserController) GetAll(c *gin.Context) {
+	pagination, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sortClause, err := parseSort(c, userSortFields, "id ASC")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := ctrl.DB.Model(&models.User{}).Scopes(homeStoreScope(c))
+	if role := c.Query("role"); role != "" {
+		query = query.Where("role = ?", role)
+	}
+	if activeStr := c.Query("active"); activeStr != "" {
+		active, err := strconv.ParseBool(activeStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid active (must be true or false)"})
+			return
+		}
+		query = query.Where("active = ?", active)
+	}
+	if search := strings.TrimSpace(c.Query("search")); search != "" {
+		pattern := "%" + search + "%"
+		query = query.Where("username ILIKE ? OR nama ILIKE ?", pattern, pattern)
+	}
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	var users []models.User
+	if err := query.Order(sortClause).Order("id").Offset(pagination.Offset()).Limit(pagination.Limit).
+		Find(&users).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	setPaginationHeaders(c, pagination, total)
+	c.JSON(http.StatusOK, users)
+}
+
+// GetByID godoc
+// @Summary Get user by ID
+// @Description Retrieve a specific user by ID
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} models.User "User details"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin role)"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/{id} [get]
+func (ctrl *UserController) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var user models.User
+	if err := ctrl.DB.Scopes(homeStoreScope(c)).First(&user, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// Create godoc
+// @Summary Create a user
+// @Description Create a user account (username & password required, role default cashier). store_id ties the user to one branch; omit it (or 0) for head office users who see all stores. Branch admins can only create non-admin users of their own store (store_id defaults to it).
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user body UserInput true "User data"
+// @Success 201 {object} models.User "Created user"
+// @Failure 400 {object} map[string]string "Validation error"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin role; branch admins: other store or admin role)"
+// @Failure 409 {object} map[string]string "Username already taken"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users [post]
+func (ctrl *UserController) Create(c *gin.Context) {
+	var input UserInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Username == nil || input.Password == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Username & password required"})
+		return
+	}
+	// Admin cabang: tanpa store_id user baru masuk tokonya sendiri
+	if homeStore := adminHomeStore(c); homeStore != nil && input.StoreID == nil {
+		storeID := *homeStore
+		input.StoreID = &storeID
+	}
+	user := models.User{Role: models.RoleCashier, Active: true}
+	if err := applyUserInput(ctrl.DB, &user, input); err != nil {
+		writeUserInputError(c, err)
+		return
+	}
+	if !checkAdminGrant(c, user.StoreID, user.Role == models.RoleAdmin) {
+		return
+	}
+	if err := ctrl.DB.Create(&user).Error; err != nil {
+		if isUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Username already taken"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, user)
+}
+
+// Update godoc
+// @Summary Update a user
+// @Description Update a user by ID (only provided fields are changed). Changing password, role, store or deactivating revokes all sessions of the user. Admins cannot demote or deactivate themselves. Branch admins can only manage users of their own store and cannot grant the admin role.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param user body UserInput true "Fields to update"
+// @Success 200 {object} models.User "Updated user"
+// @Failure 400 {object} map[string]string "Invalid ID or validation error"
+// @Failure 403 {object} map[string]string "Forbidden (requires admin role; branch admins: other store or admin role)"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 409 {object} map[string]string "Username already taken"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/{id} [put]
+func (ctrl *UserController) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	var user models.User
+	if err := ctrl.DB.Scopes(homeStoreScope(c)).First(&user, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	var input UserInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	before := user
+	if err := applyUserInput(ctrl.DB, &user, input); err != nil {
+		writeUserInputError(c, err)
+		return
+	}
+	if !checkAdminGrant(c, user.StoreID, user.Role == models.RoleAdmin && before.Role != models.RoleAdmin) {
+		return
+	}
+	// Jangan sampai admin mengunci dirinya sendiri
+	if claims, ok := auth.CurrentUser(c); ok && claims.UserID() == user.ID && (user.Role != models.RoleAdmin || !user.Active) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot demote or deactivate your own account"})
+		return
+	}
+
+	err = ctrl.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&user).Error; err != nil {
+			return err
+		}
+		// Token lama masih bawa role & toko lama, jadi wajib login ulang
+		if user.PasswordHash != before.PasswordHash || user.Role != before.Role || !user.Active || !sameStore(user.StoreID, before.StoreID) {
+			return revokeUserSessions(tx, user.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Username already taken"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
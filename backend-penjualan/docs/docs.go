@@ -15,43 +15,36 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/forecast/upload": {
-            "post": {
-                "description": "Upload CSV for sales forecast using Prophet model. CSV must have 'date' (YYYY-MM-DD) and 'projected_quantity' (or 'value') columns.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+        "/api-keys": {
+            "get": {
+                "description": "List API keys (without the secret key). Branch admins only see keys of their own store. Revoked keys are hidden unless include_revoked=true.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "forecast"
+                    "api-keys"
                 ],
-                "summary": "Upload CSV and get forecast",
+                "summary": "Get all API keys",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "CSV file with historical data",
-                        "name": "csvFile",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of days to forecast (default 30)",
-                        "name": "periods",
-                        "in": "formData"
+                        "type": "boolean",
+                        "description": "Include revoked keys",
+                        "name": "include_revoked",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "List of API keys",
                         "schema": {
-                            "$ref": "#/definitions/controllers.ForecastResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.APIKey"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid CSV or input",
+                        "description": "Invalid filter",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -59,48 +52,14 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Server error (e.g., Python exec failed)",
+                    "403": {
+                        "description": "Forbidden (requires admin role)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/products": {
-            "get": {
-                "description": "Retrieve list of all products with optional search by name",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "products"
-                ],
-                "summary": "Get all products",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Search by product name (partial match)",
-                        "name": "search",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "List of products",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Product"
-                            }
-                        }
                     },
                     "500": {
                         "description": "Internal server error",
@@ -114,7 +73,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new product with validation",
+                "description": "Create a scoped API key for an integration. Scopes: read (all GET endpoints except users \u0026 API keys), transactions:write (create transactions \u0026 orders), forecast (run forecasts). Set store_id to limit the key to one branch; keys created by branch admins are always limited to their own store. The key is returned only in this response.",
                 "consumes": [
                     "application/json"
                 ],
@@ -122,25 +81,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "api-keys"
                 ],
-                "summary": "Create a new product",
+                "summary": "Create an API key",
                 "parameters": [
                     {
-                        "description": "Product data (nama required, harga positive \u0026 max 1T)",
-                        "name": "product",
+                        "description": "Name, scopes \u0026 optional expiry",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/controllers.APIKeyInput"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created product",
+                        "description": "Created API key (key shown once)",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/controllers.APIKeyCreatedResponse"
                         }
                     },
                     "400": {
@@ -152,6 +111,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden (requires admin role; branch admins: other store)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -164,23 +132,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}": {
-            "get": {
-                "description": "Retrieve a specific product by ID",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api-keys/{id}": {
+            "delete": {
+                "description": "Revoke an API key by ID; requests using it are rejected immediately",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "api-keys"
                 ],
-                "summary": "Get product by ID",
+                "summary": "Revoke an API key",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "API key ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -188,9 +153,12 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product details",
+                        "description": "Success message",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -202,8 +170,17 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden (requires admin role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
-                        "description": "Product not found",
+                        "description": "API key not found or already revoked",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -221,9 +198,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update a product by ID with partial updates and validation",
+            }
+        },
+        "/api/v1/forecast/backtest": {
+            "post": {
+                "description": "Hold out the last N days of each product's daily transaction history, forecast them with the selected model (default auto: ML service with native fallback) from the remaining days, and report MAPE (%), MAE and RMSE against the actual sales. Days with zero actual sales are skipped for MAPE.",
                 "consumes": [
                     "application/json"
                 ],
@@ -231,45 +210,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "forecast"
                 ],
-                "summary": "Update a product",
+                "summary": "Backtest forecast accuracy per product",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Fields to update (e.g., nama, harga)",
-                        "name": "updates",
+                        "description": "Products, holdout days, date range \u0026 model",
+                        "name": "input",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/controllers.BacktestInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated product",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/controllers.BacktestResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid ID or validation error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "Product not found",
+                        "description": "Invalid input",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -278,7 +240,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Query failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -287,9 +249,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a product by ID",
+            }
+        },
+        "/api/v1/forecast/batch": {
+            "post": {
+                "description": "Forecast every active product (or the given product_ids) from its daily transaction history, running up to ` + "`" + `concurrency` + "`" + ` forecasts in parallel against the ML service (with native fallback for model auto). Products with less than 7 days of history (or unknown IDs) are listed in skipped, forecast errors in failed. Each successful forecast is stored as a run and cached like /forecast/transactions.",
                 "consumes": [
                     "application/json"
                 ],
@@ -297,21 +261,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "forecast"
                 ],
-                "summary": "Delete a product",
+                "summary": "Forecast many products at once",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Products, date range, periods, model \u0026 concurrency",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.BatchForecastInput"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Skip cached results and forecast again",
+                        "name": "no_cache",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success message",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.BatchForecastResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -319,8 +296,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid ID",
+                    "403": {
+                        "description": "Forbidden (requires admin or manager role)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -329,7 +306,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Query failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -340,51 +317,123 @@ const docTemplate = `{
                 }
             }
         },
-        "/transactions": {
+        "/api/v1/forecast/health": {
             "get": {
-                "description": "Retrieve list of all sales transactions with optional filters (product_id, start_date, search by buyer or product name)",
+                "description": "Ping the ML service health endpoint (ML_HEALTH_URL, default \u003cML_SERVICE_URL base\u003e/health) and report latency plus the circuit breaker state (closed, open, half_open). Returns 503 when the ML service is down; forecasts with model auto still work through the native fallback.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "forecast"
+                ],
+                "summary": "ML service health",
+                "responses": {
+                    "200": {
+                        "description": "ML service up",
+                        "schema": {
+                            "$ref": "#/definitions/mlclient.Health"
+                        }
+                    },
+                    "503": {
+                        "description": "ML service down",
+                        "schema": {
+                            "$ref": "#/definitions/mlclient.Health"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/forecast/jobs": {
+            "post": {
+                "description": "Queue a forecast without waiting for the ML service. Accepts the same multipart CSV/XLSX upload as /forecast/upload (csvFile, format, sheet, date_column, value_column, periods, model), a JSON body with 'points' like /forecast/upload, or the same JSON body as /forecast/transactions. Returns 202 with the job; poll GET /forecast/jobs/{id} until status is done or failed.",
                 "consumes": [
+                    "multipart/form-data",
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "forecast"
                 ],
-                "summary": "Get all transactions",
+                "summary": "Queue forecast job (async)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Filter by product ID",
-                        "name": "product_id",
-                        "in": "query"
+                        "type": "file",
+                        "description": "CSV or XLSX file with historical data (multipart)",
+                        "name": "csvFile",
+                        "in": "formData"
                     },
                     {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
                         "type": "string",
-                        "description": "Filter by start date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query"
+                        "description": "File format (default from extension, multipart)",
+                        "name": "format",
+                        "in": "formData"
                     },
                     {
                         "type": "string",
-                        "description": "Search by buyer name or product name (partial)",
-                        "name": "search",
-                        "in": "query"
+                        "description": "XLSX sheet name (default first sheet, multipart)",
+                        "name": "sheet",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date column: header name or column letter (multipart)",
+                        "name": "date_column",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Value column: header name or column letter (multipart)",
+                        "name": "value_column",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days to forecast (default 30, max 365, multipart)",
+                        "name": "periods",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "auto",
+                            "prophet",
+                            "holt_winters",
+                            "moving_average"
+                        ],
+                        "type": "string",
+                        "description": "Forecast model (default auto, multipart)",
+                        "name": "model",
+                        "in": "formData"
+                    },
+                    {
+                        "description": "Forecast from transactions (JSON); send PointsForecastInput instead to forecast from points",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.TransactionForecastInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "List of transactions (with preloaded Product)",
+                    "202": {
+                        "description": "Queued job",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Transaction"
-                            }
+                            "$ref": "#/definitions/models.ForecastJob"
                         }
                     },
                     "400": {
-                        "description": "Invalid filter format",
+                        "description": "Invalid file/points (row-by-row report) or input",
+                        "schema": {
+                            "$ref": "#/definitions/forecasting.CSVError"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large (max 5 MB)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -393,7 +442,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Query failed",
+                        "description": "Failed to queue job",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -404,9 +453,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/transactions/{id}": {
+        "/api/v1/forecast/jobs/{id}": {
             "get": {
-                "description": "Retrieve a specific transaction by ID with preloaded Product",
+                "description": "Poll a forecast job. Status is queued, running, done or failed; when done (or failed after the forecast ran) the stored run with its points is included.",
                 "consumes": [
                     "application/json"
                 ],
@@ -414,13 +463,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "forecast"
                 ],
-                "summary": "Get transaction by ID",
+                "summary": "Get forecast job status",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Transaction ID",
+                        "description": "Forecast job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -428,9 +477,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Transaction details (with Product)",
+                        "description": "Forecast job (with run when finished)",
                         "schema": {
-                            "$ref": "#/definitions/models.Transaction"
+                            "$ref": "#/definitions/models.ForecastJob"
                         }
                     },
                     "400": {
@@ -443,7 +492,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Transaction not found",
+                        "description": "Forecast job not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -461,9 +510,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Soft delete a transaction by ID (or hard delete if Unscoped)",
+            }
+        },
+        "/api/v1/forecast/runs": {
+            "get": {
+                "description": "Retrieve paginated list of stored forecast runs (without points), newest first by default. Store users only see their own store's runs. Total rows returned in X-Total-Count header.",
                 "consumes": [
                     "application/json"
                 ],
@@ -471,29 +522,176 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "forecast"
                 ],
-                "summary": "Delete a transaction",
+                "summary": "List forecast runs",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Transaction ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Success message",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                        "enum": [
+                            "upload",
+                            "json",
+                            "transactions"
+                        ],
+                        "type": "string",
+                        "description": "Filter by source",
+                        "name": "source",
+                        "in": "query"
                     },
-                    "400": {
+                    {
+                        "enum": [
+                            "success",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by model used (e.g. prophet, holt_winters)",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by product ID",
+                        "name": "product_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by input hash (same file/series)",
+                        "name": "input_hash",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields, comma separated, prefix - for descending (id, created_at, periods, model, status, mape, mae, rmse)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of forecast runs",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ForecastRun"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total runs matching the filters"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, pagination or sort",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Query failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/forecast/runs/evaluate": {
+            "post": {
+                "description": "Compare stored forecast runs (source transactions) with the actual sales that arrived after they were made and store MAPE, MAE and RMSE on each run (store users only evaluate their own store's runs). Also runs automatically every FORECAST_EVAL_INTERVAL (default 6h).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "forecast"
+                ],
+                "summary": "Evaluate stored forecasts against actual sales",
+                "responses": {
+                    "200": {
+                        "description": "Number of runs evaluated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin or manager role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Evaluation failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/forecast/runs/{id}": {
+            "get": {
+                "description": "Retrieve a stored forecast run with all its historical \u0026 forecast points",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "forecast"
+                ],
+                "summary": "Get forecast run by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Forecast run ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Forecast run with points",
+                        "schema": {
+                            "$ref": "#/definitions/models.ForecastRun"
+                        }
+                    },
+                    "400": {
                         "description": "Invalid ID",
                         "schema": {
                             "type": "object",
@@ -503,7 +701,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Transaction not found",
+                        "description": "Forecast run not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -512,7 +710,193 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Delete failed",
+                        "description": "Query failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/forecast/transactions": {
+            "post": {
+                "description": "Aggregate daily sold quantity from the transactions table (optional product_id \u0026 date range, Asia/Jakarta), send it to the forecaster as date/projected_quantity and return the forecast. No CSV upload needed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "forecast"
+                ],
+                "summary": "Forecast from stored transactions",
+                "parameters": [
+                    {
+                        "description": "Forecast input (product_id, start_date, end_date, model optional; periods default 30, max 365)",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.TransactionForecastInput"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Skip cached result and forecast again (result is re-cached)",
+                        "name": "no_cache",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ForecastResponse"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or not enough transaction history",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Server error (e.g., ML service failed)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/forecast/upload": {
+            "post": {
+                "description": "Forecast from an uploaded file or a JSON body. Multipart: CSV or XLSX file (max 5 MB) in 'csvFile' (or 'file'); format is taken from the 'format' field or the file extension. CSV delimiter (, ; tab or |) is detected automatically. For XLSX pick the sheet with 'sheet' (default first sheet); dates may be Excel date cells or YYYY-MM-DD text. Columns default to 'date' and 'projected_quantity' (or 'value'); choose others with 'date_column'/'value_column' (header name or column letter). JSON: {\"points\": [{\"date\": \"2025-01-01\", \"value\": 12}], \"periods\": 30, \"model\": \"auto\"}. All inputs are validated the same way (unique YYYY-MM-DD dates, non-negative numbers) and rejected with a row-by-row report, then normalized into the same date/projected_quantity series sent to the ML service. Default model 'auto' uses the ML service (Prophet) and falls back to the native Go engine when the ML service is down. Every run is stored (see /forecast/runs). Results are cached by input hash + periods + model (X-Cache header).",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "forecast"
+                ],
+                "summary": "Upload CSV/XLSX or JSON points and get forecast",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV or XLSX file with historical data (alias: file)",
+                        "name": "csvFile",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "File format (default from extension, else csv)",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "XLSX sheet name (default first sheet)",
+                        "name": "sheet",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date column: header name or column letter (default date)",
+                        "name": "date_column",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Value column: header name or column letter (default projected_quantity or value)",
+                        "name": "value_column",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days to forecast (default 30, max 365)",
+                        "name": "periods",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "auto",
+                            "prophet",
+                            "holt_winters",
+                            "moving_average"
+                        ],
+                        "type": "string",
+                        "description": "Forecast model (default auto)",
+                        "name": "model",
+                        "in": "formData"
+                    },
+                    {
+                        "description": "Forecast from JSON points (instead of multipart)",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.PointsForecastInput"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Skip cached result and forecast again (result is re-cached)",
+                        "name": "no_cache",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ForecastResponse"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT, MISS or BYPASS"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file/points (row-by-row report) or input",
+                        "schema": {
+                            "$ref": "#/definitions/forecasting.CSVError"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large (max 5 MB)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Server error (e.g., ML service failed)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -522,23 +906,4582 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "controllers.ForecastResponse": {
-            "description": "Response for forecast upload (includes historical + predictions)",
+        },
+        "/audit": {
+            "get": {
+                "description": "Retrieve who created, updated or deleted products and transactions, newest first, with the changed fields (before/after). Scoped to the active store like other lists. Total rows returned in X-Total-Count header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "List audit log",
+                "parameters": [
+                    {
+                        "enum": [
+                            "product",
+                            "transaction",
+                            "order"
+                        ],
+                        "type": "string",
+                        "description": "Filter by entity",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by entity ID (use with entity)",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "delete"
+                        ],
+                        "type": "string",
+                        "description": "Filter by action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "user",
+                            "api_key"
+                        ],
+                        "type": "string",
+                        "description": "Filter by actor type",
+                        "name": "actor_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by user / API key ID (use with actor_type)",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by X-Request-ID",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date, inclusive (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date, inclusive (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields, comma separated, prefix - for descending (id, entity, action, created_at)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of audit log entries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AuditLog"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total entries matching the filter"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, pagination or sort",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin or manager role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Log in with username \u0026 password. Returns a short-lived JWT access token (JWT_ACCESS_TTL, default 15m) and a refresh token (JWT_REFRESH_TTL, default 7 days).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log in",
+                "parameters": [
+                    {
+                        "description": "Username \u0026 password",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.LoginInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid username or password",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "Revoke a refresh token. The access token stays valid until it expires (JWT_ACCESS_TTL), so clients should discard it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log out",
+                "parameters": [
+                    {
+                        "description": "Refresh token to revoke",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.RefreshInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Logged out",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/me": {
+            "get": {
+                "description": "Profile of the logged-in user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get current user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or user no longer active",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password": {
+            "put": {
+                "description": "Change the password of the logged-in user. All refresh tokens of the user are revoked, so other devices must log in again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Change own password",
+                "parameters": [
+                    {
+                        "description": "Current \u0026 new password (min. 8 characters)",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ChangePasswordInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password changed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or new password too short",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Current password is wrong",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token. The refresh token is rotated: the old one is revoked and a new one returned. Reusing a revoked refresh token revokes all sessions of that user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.RefreshInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired refresh token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/customers": {
+            "get": {
+                "description": "Retrieve paginated list of customers with optional search by name, phone or email. Total rows returned in X-Total-Count header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Get all customers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search by name, phone or email (partial)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (max 500; default 50 when page is set, all rows when neither page nor limit is set)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields, comma separated, prefix - for descending (id, nama, email, created_at, updated_at)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of customers",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Customer"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total customers matching the filter"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination or sort",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new customer (nama required, email \u0026 telepon validated)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Create a new customer",
+                "parameters": [
+                    {
+                        "description": "Customer data",
+                        "name": "customer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.CustomerInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created customer",
+                        "schema": {
+                            "$ref": "#/definitions/models.Customer"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/customers/{id}": {
+            "get": {
+                "description": "Retrieve a specific customer by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Get customer by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Customer details",
+                        "schema": {
+                            "$ref": "#/definitions/models.Customer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a customer by ID (only provided fields are changed)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Update a customer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "customer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.CustomerInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated customer",
+                        "schema": {
+                            "$ref": "#/definitions/models.Customer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft delete a customer by ID. Linked transactions \u0026 orders keep their customer_id and still show the customer; a deleted customer can no longer be used for new transactions or orders.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Delete a customer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin or manager role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/customers/{id}/transactions": {
+            "get": {
+                "description": "Retrieve all transactions \u0026 orders of a customer with lifetime spend",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Get customer purchase history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purchase history",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.CustomerTransactionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Query failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/holidays": {
+            "get": {
+                "description": "Retrieve paginated holiday/event calendar, optionally filtered by year, category or date range. Total rows returned in X-Total-Count header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holidays"
+                ],
+                "summary": "Get all holidays",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter by year",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "nasional",
+                            "event"
+                        ],
+                        "type": "string",
+                        "description": "Filter by category",
+                        "name": "kategori",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields, comma separated, prefix - for descending (id, nama, tanggal, kategori)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of holidays",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Holiday"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total holidays matching the filter"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, pagination or sort",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a holiday/event date (nama \u0026 tanggal required). Moving holidays (Lebaran, Imlek, Waisak, ...) are added as one row per year with the same nama.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holidays"
+                ],
+                "summary": "Create a holiday or event",
+                "parameters": [
+                    {
+                        "description": "Holiday data",
+                        "name": "holiday",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.HolidayInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created holiday",
+                        "schema": {
+                            "$ref": "#/definitions/models.Holiday"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin or manager role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Holiday with same nama \u0026 tanggal already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/holidays/{id}": {
+            "get": {
+                "description": "Retrieve a specific holiday/event by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holidays"
+                ],
+                "summary": "Get holiday by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Holiday ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Holiday details",
+                        "schema": {
+                            "$ref": "#/definitions/models.Holiday"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Holiday not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a holiday/event by ID (only provided fields are changed)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holidays"
+                ],
+                "summary": "Update a holiday",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Holiday ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "holiday",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.HolidayInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated holiday",
+                        "schema": {
+                            "$ref": "#/definitions/models.Holiday"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin or manager role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Holiday not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Holiday with same nama \u0026 tanggal already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a holiday/event by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holidays"
+                ],
+                "summary": "Delete a holiday",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Holiday ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin or manager role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Holiday not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/recommendations": {
+            "get": {
+                "description": "Forecast daily demand per product (last lookback_days of transactions) over its lead time plus a review period, then compute reorder point = lead time demand + safety_stock and order-up-to level = (lead time + review) demand + safety_stock. needs_reorder is true when stok \u003c= reorder point; suggested_order_qty = order-up-to - stok. Products with less than 7 days of history use their average daily sales. Products are paginated (X-Total-Count header); reorder candidates come first within a page.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Restock recommendations",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by product IDs (repeat or comma separated)",
+                        "name": "product_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days of sales history to forecast from (default 90, max 730)",
+                        "name": "lookback_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days between purchase orders, added on top of lead time for the order-up-to level (default 7); lead_time_days + review_days must not exceed 365",
+                        "name": "review_days",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "auto",
+                            "prophet",
+                            "holt_winters",
+                            "moving_average"
+                        ],
+                        "type": "string",
+                        "description": "Forecast model (default auto)",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controllers.RestockRecommendation"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total products matching the filter"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Query failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "get": {
+                "description": "Retrieve paginated list of multi-item orders (with items \u0026 products) of the active store, optional search by buyer name. Total rows returned in X-Total-Count header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Get all orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search by buyer name (partial)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (max 500; default 50 when page is set, all rows when neither page nor limit is set)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields, comma separated, prefix - for descending (id, nama_pembeli, total_quantity, total, created_at, updated_at)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of orders",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Order"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total orders matching the filter"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination or sort",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Query failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a multi-item order in the active store: snapshot unit price per item, compute line \u0026 order totals, decrement stock for every product; recorded in the audit log. All products must belong to the store; head office must send X-Store-ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Create a new order",
+                "parameters": [
+                    {
+                        "description": "Order input (nama_pembeli and/or customer_id, items[product_id, quantity])",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.CreateOrderInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created order (with items)",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Product or customer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient stock",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Create failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "description": "Retrieve a specific order by ID with its items and products",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Get order by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order details",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Query failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update nama_pembeli/customer_id and/or replace all items (old items' stock is restored, new items reserve stock and snapshot current prices)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Update an order partially",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update (optional)",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.UpdateOrderInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated order (with items)",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin or manager role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Order, product or customer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient stock",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Update failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "Retrieve paginated list of products of the active store (X-Store-ID or the user's store; head office without X-Store-ID sees all stores) with optional search by name. Total rows returned in X-Total-Count header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get all products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search by product name (partial match)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also return products in the trash",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (max 500; default 50 when page is set, all rows when neither page nor limit is set)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields, comma separated, prefix - for descending (id, nama, harga, stok, created_at, updated_at, deleted_at)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of products",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Product"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total products matching the filter"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination or sort",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new product with validation in the active store (head office must send X-Store-ID)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Create a new product",
+                "parameters": [
+                    {
+                        "description": "Product data (nama required, harga positive \u0026 max 1T, stok \u003e= 0, lead_time_days 0-365 default 7, safety_stock default 0)",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created product",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin or manager role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/products/trash": {
+            "get": {
+                "description": "Retrieve paginated products in the trash of the active store, newest deleted first. Total rows returned in X-Total-Count header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "List deleted products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search by product name (partial match)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields, comma separated, prefix - for descending (id, nama, harga, stok, created_at, updated_at, deleted_at)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of deleted products",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Product"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total products in the trash"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination or sort",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin or manager role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Permanently delete products in the trash of the active store (optionally only those deleted at least older_than_days ago). Products still referenced by transactions or orders are kept and counted in skipped.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Permanently delete products in the trash",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only purge products deleted at least this many days ago",
+                        "name": "older_than_days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Number of purged and skipped products",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid older_than_days",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Retrieve a specific product by ID (products in the trash only with include_deleted=true)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get product by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also find products in the trash",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product details",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a product by ID with partial updates and validation. Only nama, harga, stok, lead_time_days \u0026 safety_stock can be changed; every change is recorded in the audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Update a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update (e.g., nama, harga, stok, lead_time_days, safety_stock)",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated product",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID, unknown field or validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin or manager role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft delete a product by ID: it moves to the trash (GET /products/trash), can no longer be sold and can be restored. Its transactions are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Delete a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin or manager role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/restore": {
+            "post": {
+                "description": "Move a product out of the trash so it can be sold again (stock is kept as it was)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Restore a deleted product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored product",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin or manager role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found in trash",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/sales": {
+            "get": {
+                "description": "Revenue, quantity and transaction count grouped by day/week/month (Asia/Jakarta), optionally split per product or per store. Covers the active store (X-Store-ID or the user's store); head-office users without X-Store-ID get a consolidated report across all stores (use split=store for a per-branch breakdown). Accepts the same filters as GET /transactions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Sales summary report",
+                "parameters": [
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "description": "Time bucket (default day)",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "product",
+                            "store"
+                        ],
+                        "type": "string",
+                        "description": "Split each bucket per product or per store",
+                        "name": "split",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by product ID (repeat or comma separated for multiple)",
+                        "name": "product_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date, inclusive (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date, inclusive (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "description": "Date column used for bucketing \u0026 range: created_at (default) or updated_at",
+                        "name": "date_field",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sales report",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.SalesReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Query failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/stores": {
+            "get": {
+                "description": "Retrieve all stores (branches), optionally only active ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Get all stores",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Filter by active flag",
+                        "name": "active",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of stores",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Store"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a store/branch (kode \u0026 nama required)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Create a store",
+                "parameters": [
+                    {
+                        "description": "Store data",
+                        "name": "store",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.StoreInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created store",
+                        "schema": {
+                            "$ref": "#/definitions/models.Store"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Store kode already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{id}": {
+            "get": {
+                "description": "Retrieve a specific store by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Get store by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Store ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Store details",
+                        "schema": {
+                            "$ref": "#/definitions/models.Store"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Store not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a store by ID (only provided fields are changed). Set active=false to close a branch; its data stays available for reports.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Update a store",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Store ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "store",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.StoreInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated store",
+                        "schema": {
+                            "$ref": "#/definitions/models.Store"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Store not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Store kode already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions": {
+            "get": {
+                "description": "Retrieve paginated list of sales transactions of the active store (X-Store-ID or the user's store; head office without X-Store-ID sees all stores) with combinable filters (product_id list, date range on created_at/updated_at, total \u0026 quantity ranges, search by buyer or product name). Dates use Asia/Jakarta. Total rows returned in X-Total-Count header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get all transactions",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by product ID (repeat or comma separated for multiple)",
+                        "name": "product_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start date, inclusive (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by end date, inclusive (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "description": "Date column used by start_date/end_date: created_at (default) or updated_at",
+                        "name": "date_field",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum total",
+                        "name": "min_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum total",
+                        "name": "max_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum quantity",
+                        "name": "min_quantity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum quantity",
+                        "name": "max_quantity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by buyer name or product name (partial)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also return transactions in the trash",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (max 500; default 50 when page is set, all rows when neither page nor limit is set)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields, comma separated, prefix - for descending (id, nama_pembeli, product_id, quantity, harga, total, created_at, updated_at, deleted_at)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of transactions (with preloaded Product)",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Transaction"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total transactions matching the filters"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, pagination or sort format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Query failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/trash": {
+            "get": {
+                "description": "Retrieve paginated transactions in the trash of the active store, newest deleted first. Accepts the same filters as GET /transactions. Total rows returned in X-Total-Count header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "List deleted transactions",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by product ID (repeat or comma separated for multiple)",
+                        "name": "product_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start date, inclusive (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by end date, inclusive (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by buyer name or product name (partial)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields, comma separated, prefix - for descending (id, nama_pembeli, product_id, quantity, harga, total, created_at, updated_at, deleted_at)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of deleted transactions (with preloaded Product)",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Transaction"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total transactions in the trash"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, pagination or sort format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin or manager role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Query failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Permanently delete transactions in the trash of the active store (optionally only those deleted at least older_than_days ago). Stock is not changed; it was already returned when they were deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Permanently delete transactions in the trash",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only purge transactions deleted at least this many days ago",
+                        "name": "older_than_days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Number of purged transactions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid older_than_days",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Purge failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}": {
+            "get": {
+                "description": "Retrieve a specific transaction by ID with preloaded Product (transactions in the trash only with include_deleted=true)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get transaction by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also find transactions in the trash",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction details (with Product)",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Query failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft delete a transaction by ID: it moves to the trash (GET /transactions/trash), is excluded from reports \u0026 forecasts and its quantity is returned to the product stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Delete a transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin or manager role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Delete failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/restore": {
+            "post": {
+                "description": "Move a transaction out of the trash and take its quantity from the product stock again. Fails with 409 if the stock is no longer sufficient, or 404 if the product itself is in the trash.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Restore a deleted transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored transaction (with Product)",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin or manager role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found in trash or product deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient stock",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Restore failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Retrieve paginated users, optionally filtered by role, active flag or username/nama search. Branch admins only see users of their own store. Total rows returned in X-Total-Count header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get all users",
+                "parameters": [
+                    {
+                        "enum": [
+                            "admin",
+                            "manager",
+                            "cashier"
+                        ],
+                        "type": "string",
+                        "description": "Filter by role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by active flag",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search username or nama (case-insensitive)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields, comma separated, prefix - for descending (id, username, nama, role, last_login_at, created_at)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of users",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total users matching the filter"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, pagination or sort",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a user account (username \u0026 password required, role default cashier). store_id ties the user to one branch; omit it (or 0) for head office users who see all stores. Branch admins can only create non-admin users of their own store (store_id defaults to it).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create a user",
+                "parameters": [
+                    {
+                        "description": "User data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.UserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created user",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin role; branch admins: other store or admin role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Username already taken",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Retrieve a specific user by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User details",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a user by ID (only provided fields are changed). Changing password, role, store or deactivating revokes all sessions of the user. Admins cannot demote or deactivate themselves. Branch admins can only manage users of their own store and cannot grant the admin role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.UserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated user",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (requires admin role; branch admins: other store or admin role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Username already taken",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "controllers.APIKeyCreatedResponse": {
+            "description": "Newly created API key. The key is only shown once; send it as \"Authorization: Bearer \u003ckey\u003e\".",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by_id": {
+                    "description": "User admin yang membuat",
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "description": "Null = gak pernah expired",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "last_used_ip": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Awal key (mis. pnj_AbC123) buat identifikasi di log/UI",
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "read / transactions:write / forecast",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "store_id": {
+                    "description": "Null = semua toko",
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.APIKeyInput": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_in_days": {
+                    "description": "Kosong = gak pernah expired",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Nama integrasi, mis. \"ERP\" atau \"ML service\"",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "read / transactions:write / forecast",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "store_id": {
+                    "description": "Toko yang boleh diakses; 0 = semua toko (pilih lewat X-Store-ID), admin cabang: tokonya sendiri",
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.BacktestInput": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "description": "YYYY-MM-DD, optional",
+                    "type": "string"
+                },
+                "holdout_days": {
+                    "description": "Jumlah hari terakhir yang disembunyikan lalu diprediksi (default 14, max 365)",
+                    "type": "integer"
+                },
+                "model": {
+                    "description": "auto (default), prophet, holt_winters, moving_average",
+                    "type": "string"
+                },
+                "product_ids": {
+                    "description": "Kosong = semua produk yang punya transaksi di rentang tanggal",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "start_date": {
+                    "description": "YYYY-MM-DD, optional",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.BacktestProductResult": {
+            "type": "object",
+            "properties": {
+                "accuracy": {
+                    "$ref": "#/definitions/forecasting.Accuracy"
+                },
+                "error": {
+                    "description": "Kenapa produk ini gak bisa di-backtest",
+                    "type": "string"
+                },
+                "fallback_reason": {
+                    "type": "string"
+                },
+                "model": {
+                    "description": "Model yang benar-benar dipakai",
+                    "type": "string"
+                },
+                "nama_produk": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "train_days": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.BacktestResponse": {
+            "type": "object",
+            "properties": {
+                "holdout_days": {
+                    "type": "integer"
+                },
+                "model": {
+                    "description": "Model yang diminta",
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.BacktestProductResult"
+                    }
+                }
+            }
+        },
+        "controllers.BatchForecastInput": {
+            "type": "object",
+            "properties": {
+                "concurrency": {
+                    "description": "Forecast paralel maksimal (default FORECAST_BATCH_CONCURRENCY atau 4, max 16)",
+                    "type": "integer"
+                },
+                "end_date": {
+                    "description": "YYYY-MM-DD, optional",
+                    "type": "string"
+                },
+                "model": {
+                    "description": "auto (default), prophet, holt_winters, moving_average",
+                    "type": "string"
+                },
+                "periods": {
+                    "description": "Default 30, max 365",
+                    "type": "integer"
+                },
+                "product_ids": {
+                    "description": "Kosong = semua produk aktif",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "start_date": {
+                    "description": "YYYY-MM-DD, optional",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.BatchForecastResponse": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.BatchForecastSkipped"
+                    }
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.BatchForecastResult"
+                    }
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.BatchForecastSkipped"
+                    }
+                }
+            }
+        },
+        "controllers.BatchForecastResult": {
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "description": "HIT / MISS / BYPASS",
+                    "type": "string"
+                },
+                "fallback_reason": {
+                    "description": "Diisi kalau ML down \u0026 pakai engine native",
+                    "type": "string"
+                },
+                "forecast": {
+                    "description": "Prediksi + interval kepercayaan per hari",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forecasting.ResultPoint"
+                    }
+                },
+                "historical": {
+                    "description": "Actual + fitted value per hari",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forecasting.ResultPoint"
+                    }
+                },
+                "model": {
+                    "description": "Model yang benar-benar dipakai",
+                    "type": "string"
+                },
+                "nama_produk": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "run_id": {
+                    "description": "ID forecast run yang tersimpan",
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.BatchForecastSkipped": {
+            "type": "object",
+            "properties": {
+                "history_days": {
+                    "type": "integer"
+                },
+                "nama_produk": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.ChangePasswordInput": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.CreateOrderInput": {
+            "type": "object",
+            "properties": {
+                "customer_id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.OrderItemInput"
+                    }
+                },
+                "nama_pembeli": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.CustomerInput": {
+            "type": "object",
+            "properties": {
+                "alamat": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "telepon": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.CustomerTransactionsResponse": {
+            "type": "object",
+            "properties": {
+                "customer": {
+                    "$ref": "#/definitions/models.Customer"
+                },
+                "lifetime_spend": {
+                    "type": "number"
+                },
+                "order_count": {
+                    "type": "integer"
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Order"
+                    }
+                },
+                "total_quantity": {
+                    "type": "integer"
+                },
+                "transaction_count": {
+                    "type": "integer"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Transaction"
+                    }
+                }
+            }
+        },
+        "controllers.ForecastResponse": {
+            "description": "Forecast result: historical points (actual + fitted yhat) and forecast points (yhat with 95% interval). trend/weekly/yearly components are included when the model provides them (Prophet; Holt-Winters gives trend + weekly).",
+            "type": "object",
+            "properties": {
+                "fallback_reason": {
+                    "description": "Diisi kalau ML down \u0026 pakai engine native",
+                    "type": "string"
+                },
+                "forecast": {
+                    "description": "Prediksi + interval kepercayaan per hari",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forecasting.ResultPoint"
+                    }
+                },
+                "historical": {
+                    "description": "Actual + fitted value per hari",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forecasting.ResultPoint"
+                    }
+                },
+                "model": {
+                    "description": "Model yang benar-benar dipakai",
+                    "type": "string"
+                },
+                "run_id": {
+                    "description": "ID forecast run yang tersimpan",
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.HolidayInput": {
+            "type": "object",
+            "properties": {
+                "kategori": {
+                    "description": "nasional (default) / event",
+                    "type": "string"
+                },
+                "lower_window": {
+                    "description": "\u003c= 0, mis. -7 = efek mulai seminggu sebelumnya",
+                    "type": "integer"
+                },
+                "nama": {
+                    "description": "Nama sama di tiap tahun biar efeknya dipelajari (mis. \"Hari Raya Idul Fitri\")",
+                    "type": "string"
+                },
+                "tanggal": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "upper_window": {
+                    "description": "\u003e= 0",
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.LoginInput": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.OrderItemInput": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.PointsForecastInput": {
+            "type": "object",
+            "properties": {
+                "model": {
+                    "description": "auto (default), prophet, holt_winters, moving_average",
+                    "type": "string"
+                },
+                "periods": {
+                    "description": "Default 30, max 365",
+                    "type": "integer"
+                },
+                "points": {
+                    "description": "Series harian, tanggal YYYY-MM-DD unik",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forecasting.InputPoint"
+                    }
+                }
+            }
+        },
+        "controllers.RefreshInput": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.RestockRecommendation": {
+            "type": "object",
+            "properties": {
+                "avg_daily_demand": {
+                    "description": "Rata-rata forecast per hari",
+                    "type": "number"
+                },
+                "days_of_cover": {
+                    "description": "Stok cukup buat berapa hari (null kalau demand 0)",
+                    "type": "number"
+                },
+                "error": {
+                    "description": "Kenapa forecast produk ini gagal",
+                    "type": "string"
+                },
+                "fallback_reason": {
+                    "description": "Diisi kalau ML down \u0026 pakai engine native",
+                    "type": "string"
+                },
+                "lead_time_days": {
+                    "type": "integer"
+                },
+                "lead_time_demand": {
+                    "description": "Forecast demand selama lead time",
+                    "type": "number"
+                },
+                "model": {
+                    "description": "Model forecast yang dipakai (average kalau histori kurang)",
+                    "type": "string"
+                },
+                "nama_produk": {
+                    "type": "string"
+                },
+                "needs_reorder": {
+                    "type": "boolean"
+                },
+                "order_up_to": {
+                    "description": "Target stok setelah order datang (lead time + review period)",
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "reorder_point": {
+                    "description": "Order kalau stok \u003c= angka ini",
+                    "type": "integer"
+                },
+                "safety_stock": {
+                    "type": "integer"
+                },
+                "stok": {
+                    "type": "integer"
+                },
+                "suggested_order_qty": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.SalesReportBucket": {
+            "type": "object",
+            "properties": {
+                "period": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "product_nama": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "store_id": {
+                    "type": "integer"
+                },
+                "store_nama": {
+                    "type": "string"
+                },
+                "transaction_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.SalesReportResponse": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.SalesReportBucket"
+                    }
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "group_by": {
+                    "type": "string"
+                },
+                "split": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "description": "Kosong = konsolidasi semua toko",
+                    "type": "integer"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "totals": {
+                    "$ref": "#/definitions/controllers.SalesReportTotals"
+                }
+            }
+        },
+        "controllers.SalesReportTotals": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "transaction_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.StoreInput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "false = gak bisa input produk/transaksi/order baru",
+                    "type": "boolean"
+                },
+                "alamat": {
+                    "type": "string"
+                },
+                "kode": {
+                    "description": "Unik (case-insensitive), mis. JKT01",
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.TokenResponse": {
+            "description": "Access token (send as \"Authorization: Bearer \u003caccess_token\u003e\") plus refresh token for POST /auth/refresh",
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "description": "Detik sampai access token expired",
+                    "type": "integer"
+                },
+                "refresh_expires_at": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                }
+            }
+        },
+        "controllers.TransactionForecastInput": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "description": "YYYY-MM-DD, optional",
+                    "type": "string"
+                },
+                "model": {
+                    "description": "auto (default), prophet, holt_winters, moving_average",
+                    "type": "string"
+                },
+                "periods": {
+                    "description": "Default 30, max 365",
+                    "type": "integer"
+                },
+                "product_id": {
+                    "description": "Kosong = semua produk",
+                    "type": "integer"
+                },
+                "start_date": {
+                    "description": "YYYY-MM-DD, optional",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.UpdateOrderInput": {
+            "type": "object",
+            "properties": {
+                "customer_id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.OrderItemInput"
+                    }
+                },
+                "nama_pembeli": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.UserInput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "false = gak bisa login, semua sesi di-revoke",
+                    "type": "boolean"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Min. 8 karakter",
+                    "type": "string"
+                },
+                "role": {
+                    "description": "admin / manager / cashier (default cashier)",
+                    "type": "string"
+                },
+                "store_id": {
+                    "description": "Toko asal; 0 = kantor pusat (akses semua toko)",
+                    "type": "integer"
+                },
+                "username": {
+                    "description": "Huruf kecil, unik (case-insensitive)",
+                    "type": "string"
+                }
+            }
+        },
+        "forecasting.Accuracy": {
+            "type": "object",
+            "properties": {
+                "mae": {
+                    "description": "Mean absolute error (unit quantity)",
+                    "type": "number"
+                },
+                "mape": {
+                    "description": "Mean absolute percentage error (%), hari dengan actual 0 di-skip; null kalau semua 0",
+                    "type": "number"
+                },
+                "points": {
+                    "description": "Jumlah hari yang dibandingkan",
+                    "type": "integer"
+                },
+                "rmse": {
+                    "description": "Root mean squared error (unit quantity)",
+                    "type": "number"
+                }
+            }
+        },
+        "forecasting.CSVError": {
+            "type": "object",
+            "properties": {
+                "delimiter": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forecasting.RowError"
+                    }
+                },
+                "sheet": {
+                    "type": "string"
+                },
+                "truncated": {
+                    "description": "true kalau error lebih dari yang dilaporkan",
+                    "type": "boolean"
+                }
+            }
+        },
+        "forecasting.InputPoint": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 12
+                }
+            }
+        },
+        "forecasting.ResultPoint": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "description": "Penjualan aktual (historical saja)",
+                    "type": "number",
+                    "example": 12
+                },
+                "date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2025-01-31"
+                },
+                "holidays": {
+                    "description": "Efek holiday/event di tanggal ini",
+                    "type": "number",
+                    "example": 3.2
+                },
+                "trend": {
+                    "description": "Komponen trend",
+                    "type": "number",
+                    "example": 10.9
+                },
+                "weekly": {
+                    "description": "Komponen musiman mingguan",
+                    "type": "number",
+                    "example": 0.5
+                },
+                "yearly": {
+                    "description": "Komponen musiman tahunan (Prophet)",
+                    "type": "number",
+                    "example": -0.1
+                },
+                "yhat": {
+                    "description": "Nilai prediksi / fitted",
+                    "type": "number",
+                    "example": 11.4
+                },
+                "yhat_lower": {
+                    "description": "Batas bawah interval kepercayaan 95%",
+                    "type": "number",
+                    "example": 8.2
+                },
+                "yhat_upper": {
+                    "description": "Batas atas interval kepercayaan 95%",
+                    "type": "number",
+                    "example": 14.6
+                }
+            }
+        },
+        "forecasting.RowError": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "mlclient.Health": {
+            "type": "object",
+            "properties": {
+                "breaker": {
+                    "description": "closed / open / half_open",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "up / down",
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by_id": {
+                    "description": "User admin yang membuat",
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "description": "Null = gak pernah expired",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "last_used_ip": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Awal key (mis. pnj_AbC123) buat identifikasi di log/UI",
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "read / transactions:write / forecast",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "store_id": {
+                    "description": "Null = semua toko",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AuditChange": {
+            "type": "object",
+            "properties": {
+                "after": {},
+                "before": {}
+            }
+        },
+        "models.AuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "create / update / delete / restore / purge",
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "integer"
+                },
+                "actor_name": {
+                    "description": "Username / nama API key saat itu",
+                    "type": "string"
+                },
+                "actor_type": {
+                    "description": "user / api_key",
+                    "type": "string"
+                },
+                "changes": {
+                    "description": "Cuma field yang berubah",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.AuditChange"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity": {
+                    "description": "product / transaction / order",
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Customer": {
+            "type": "object",
+            "properties": {
+                "alamat": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "Soft delete: riwayat belanja tetap ter-link",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "telepon": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ForecastJob": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "input_filename": {
+                    "description": "Nama file kalau upload",
+                    "type": "string"
+                },
+                "input_hash": {
+                    "description": "SHA-256 input",
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "periods": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "run": {
+                    "description": "Diisi di GET kalau status done/failed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ForecastRun"
+                        }
+                    ]
+                },
+                "run_id": {
+                    "description": "Forecast run hasil job (kalau sudah jalan)",
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "upload / json / transactions",
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "queued / running / done / failed",
+                    "type": "string"
+                },
+                "store_id": {
+                    "description": "Toko pembuat job; null = kantor pusat",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ForecastPoint": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "holidays": {
+                    "description": "Efek holiday/event",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "historical / forecast",
+                    "type": "string"
+                },
+                "run_id": {
+                    "type": "integer"
+                },
+                "trend": {
+                    "description": "Komponen trend (kalau model menyediakan)",
+                    "type": "number"
+                },
+                "weekly": {
+                    "description": "Komponen musiman mingguan",
+                    "type": "number"
+                },
+                "yearly": {
+                    "description": "Komponen musiman tahunan (Prophet)",
+                    "type": "number"
+                },
+                "yhat": {
+                    "type": "number"
+                },
+                "yhat_lower": {
+                    "type": "number"
+                },
+                "yhat_upper": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.ForecastRun": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Pesan error kalau failed",
+                    "type": "string"
+                },
+                "evaluated_at": {
+                    "type": "string"
+                },
+                "evaluated_points": {
+                    "description": "Jumlah hari forecast yang sudah ada actual-nya",
+                    "type": "integer"
+                },
+                "fallback_reason": {
+                    "description": "Kenapa pindah ke engine native",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "input_filename": {
+                    "description": "Nama file kalau upload",
+                    "type": "string"
+                },
+                "input_hash": {
+                    "description": "SHA-256 file/series input",
+                    "type": "string"
+                },
+                "mae": {
+                    "type": "number"
+                },
+                "mape": {
+                    "description": "Akurasi vs penjualan aktual (diisi evaluator terjadwal)",
+                    "type": "number"
+                },
+                "model": {
+                    "description": "Model yang benar-benar dipakai",
+                    "type": "string"
+                },
+                "model_requested": {
+                    "description": "Model yang diminta (auto, prophet, ...)",
+                    "type": "string"
+                },
+                "periods": {
+                    "description": "Jumlah hari yang diprediksi",
+                    "type": "integer"
+                },
+                "points": {
+                    "description": "Hasil (historical + forecast)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ForecastPoint"
+                    }
+                },
+                "product_id": {
+                    "description": "Kalau forecast per produk",
+                    "type": "integer"
+                },
+                "rmse": {
+                    "type": "number"
+                },
+                "source": {
+                    "description": "upload / json / transactions",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "success / failed",
+                    "type": "string"
+                },
+                "store_id": {
+                    "description": "Toko pembuat run; null = kantor pusat",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Holiday": {
             "type": "object",
             "properties": {
-                "eda_stats": {
-                    "type": "object",
-                    "additionalProperties": true
+                "created_at": {
+                    "type": "string"
                 },
-                "forecast": {
+                "id": {
+                    "type": "integer"
+                },
+                "kategori": {
+                    "description": "nasional / event",
+                    "type": "string"
+                },
+                "lower_window": {
+                    "description": "Efek mulai N hari sebelumnya (\u003c= 0, mis. -7 jelang Lebaran)",
+                    "type": "integer"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "tanggal": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "upper_window": {
+                    "description": "Efek sampai N hari sesudahnya (\u003e= 0)",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Order": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "customer": {
+                    "$ref": "#/definitions/models.Customer"
+                },
+                "customer_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "additionalProperties": true
+                        "$ref": "#/definitions/models.OrderItem"
                     }
+                },
+                "nama_pembeli": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "description": "Semua item harus produk toko ini",
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "number"
+                },
+                "total_quantity": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrderItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "harga": {
+                    "description": "Harga satuan saat order",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "product": {
+                    "$ref": "#/definitions/models.Product"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "description": "Quantity * Harga",
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
@@ -549,7 +5492,9 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "deleted_at": {
-                    "type": "string"
+                    "description": "Soft delete: null = aktif, terisi = di trash",
+                    "type": "string",
+                    "format": "date-time"
                 },
                 "harga": {
                     "description": "FIXED: (15,2) biar max triliunan",
@@ -558,6 +5503,50 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "lead_time_days": {
+                    "description": "Lama restock dari supplier (hari), 0 = supplier same-day",
+                    "type": "integer"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "safety_stock": {
+                    "description": "Stok cadangan minimal",
+                    "type": "integer"
+                },
+                "stok": {
+                    "description": "Stok di rak, gak boleh minus",
+                    "type": "integer"
+                },
+                "store_id": {
+                    "description": "Toko pemilik produk \u0026 stoknya",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Store": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Toko nonaktif gak bisa input data baru (default true diisi handler, bukan tag GORM)",
+                    "type": "boolean"
+                },
+                "alamat": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kode": {
+                    "description": "Kode singkat, mis. JKT01",
+                    "type": "string"
+                },
                 "nama": {
                     "type": "string"
                 },
@@ -572,8 +5561,17 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "customer": {
+                    "$ref": "#/definitions/models.Customer"
+                },
+                "customer_id": {
+                    "description": "Optional, kosong untuk pembeli walk-in",
+                    "type": "integer"
+                },
                 "deleted_at": {
-                    "type": "string"
+                    "description": "Soft delete: null = aktif, terisi = di trash",
+                    "type": "string",
+                    "format": "date-time"
                 },
                 "harga": {
                     "type": "number"
@@ -593,6 +5591,10 @@ const docTemplate = `{
                 "quantity": {
                     "type": "integer"
                 },
+                "store_id": {
+                    "description": "Sama dengan toko produknya",
+                    "type": "integer"
+                },
                 "total": {
                     "type": "number"
                 },
@@ -600,8 +5602,56 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "models.User": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Default true di handler \u0026 migration (tag default GORM bikin false ikut di-skip waktu insert)",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_login_at": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "admin / manager / cashier",
+                    "type": "string"
+                },
+                "store_id": {
+                    "description": "Toko tempat user bertugas; null = kantor pusat (semua toko)",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "JWT access token dari POST /api/v1/auth/login (\"Bearer \u003caccess_token\u003e\") atau API key (\"Bearer pnj_...\")",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
+    },
+    "security": [
+        {
+            "BearerAuth": []
         }
-    }
+    ]
 }`
 
 // SwaggerInfo holds exported Swagger Info so clients can modify it
@@ -610,8 +5660,8 @@ var SwaggerInfo = &swag.Spec{
 	Host:             "",
 	BasePath:         "",
 	Schemes:          []string{},
-	Title:            "",
-	Description:      "",
+	Title:            "Backend Penjualan API",
+	Description:      "API penjualan, stok & forecast. Semua endpoint (kecuali /api/v1/auth/login, /refresh & /logout) wajib login atau API key.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
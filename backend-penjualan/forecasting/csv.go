@@ -0,0 +1,77 @@
+package forecasting
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseCSV baca CSV upload jadi series harian. Delimiter (, ; tab |) dideteksi otomatis.
+// Wajib ada kolom 'date' (YYYY-MM-DD) dan 'projected_quantity' (atau 'value'); file 2 kolom tanpa header
+// dianggap date,value. Semua baris divalidasi dulu (tanggal, angka, duplikat) dan dilaporkan sekaligus
+// lewat *CSVError. Series dikembalikan terurut berdasarkan tanggal.
+func ParseCSV(r io.Reader) ([]Point, error) {
+	return ParseCSVWithOptions(r, TableOptions{})
+}
+
+// ParseCSVWithOptions sama dengan ParseCSV tapi kolom date/value bisa dipilih (nama header atau huruf kolom)
+func ParseCSVWithOptions(r io.Reader, opts TableOptions) ([]Point, error) {
+	data, err := readLimited(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // UTF-8 BOM dari Excel
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, &CSVError{Message: "CSV file is empty"}
+	}
+
+	delimiter := detectDelimiter(data)
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = delimiter
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1 // Jumlah kolom dicek sendiri biar bisa dilaporkan per baris
+	rows, err := reader.ReadAll()
+	if err != nil {
+		csvErr := &CSVError{Message: "invalid CSV", Delimiter: string(delimiter)}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			csvErr.Rows = []RowError{{Row: parseErr.StartLine, Message: parseErr.Err.Error()}}
+		}
+		return nil, csvErr
+	}
+
+	series, err := parseTable(rows, opts, false)
+	var csvErr *CSVError
+	if errors.As(err, &csvErr) {
+		csvErr.Delimiter = string(delimiter)
+	}
+	return series, err
+}
+
+// readLimited baca input maksimal MaxUploadBytes
+func readLimited(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxUploadBytes+1))
+	if err != nil {
+		return nil, &CSVError{Message: fmt.Sprintf("failed to read file: %v", err)}
+	}
+	if len(data) > MaxUploadBytes {
+		return nil, ErrFileTooLarge
+	}
+	return data, nil
+}
+
+// detectDelimiter pilih delimiter yang paling sering muncul di baris pertama (default koma)
+func detectDelimiter(data []byte) rune {
+	firstLine, _ := bufio.NewReader(bytes.NewReader(data)).ReadString('\n')
+	best, bestCount := ',', 0
+	for _, candidate := range []rune{',', ';', '\t', '|'} {
+		if count := strings.Count(firstLine, string(candidate)); count > bestCount {
+			best, bestCount = candidate, count
+		}
+	}
+	return best
+}
@@ -0,0 +1,131 @@
+package forecasting
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseCSVWithOptions(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		opts          TableOptions
+		want          []string // "YYYY-MM-DD=value", terurut
+		wantErr       string
+		wantRows      []int
+		wantDelimiter string
+	}{
+		{
+			name:  "header with projected_quantity, sorted by date",
+			input: "date,projected_quantity\n2025-01-03,5\n2025-01-01,3\n2025-01-02,4.5\n",
+			want:  []string{"2025-01-01=3", "2025-01-02=4.5", "2025-01-03=5"},
+		},
+		{
+			name:  "semicolon delimiter and value column",
+			input: "date;value\n2025-01-01;1\n2025-01-02;2\n",
+			want:  []string{"2025-01-01=1", "2025-01-02=2"},
+		},
+		{
+			name:  "tab delimiter with extra columns",
+			input: "id\tdate\tvalue\n1\t2025-01-01\t7\n",
+			want:  []string{"2025-01-01=7"},
+		},
+		{
+			name:  "two columns without header",
+			input: "2025-01-01,1\n2025-01-02,2\n",
+			want:  []string{"2025-01-01=1", "2025-01-02=2"},
+		},
+		{
+			name:  "utf-8 BOM and blank lines",
+			input: "\xef\xbb\xbfdate,value\n2025-01-01,1\n\n2025-01-02,2\n",
+			want:  []string{"2025-01-01=1", "2025-01-02=2"},
+		},
+		{
+			name:  "columns chosen by header name",
+			input: "tanggal,qty,harga\n2025-01-01,2,1000\n",
+			opts:  TableOptions{DateColumn: "Tanggal", ValueColumn: "qty"},
+			want:  []string{"2025-01-01=2"},
+		},
+		{
+			name:  "columns chosen by letter",
+			input: "qty,tanggal\n2,2025-01-01\n",
+			opts:  TableOptions{DateColumn: "B", ValueColumn: "A"},
+			want:  []string{"2025-01-01=2"},
+		},
+		{
+			name:    "empty file",
+			input:   "  \n",
+			wantErr: "CSV file is empty",
+		},
+		{
+			name:     "missing columns",
+			input:    "tanggal,qty\n2025-01-01,2\n",
+			wantErr:  "file must have 'date' and 'projected_quantity' (or 'value') columns",
+			wantRows: []int{1},
+		},
+		{
+			name:     "chosen column not found",
+			input:    "date,value\n2025-01-01,2\n",
+			opts:     TableOptions{ValueColumn: "qty"},
+			wantErr:  `column not found (date_column "", value_column "qty")`,
+			wantRows: []int{1},
+		},
+		{
+			name:     "header only",
+			input:    "date,value\n",
+			wantErr:  "file must have a header and at least one data row",
+			wantRows: nil,
+		},
+		{
+			name:          "invalid rows reported together",
+			input:         "date;value\n2025-01-01;1\n01/02/2025;2\n2025-01-03;-1\n2025-01-01;4\n2025-01-05;abc\n2025-01-06\n",
+			wantErr:       "file has invalid rows",
+			wantRows:      []int{3, 4, 5, 6, 7},
+			wantDelimiter: ";",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series, err := ParseCSVWithOptions(strings.NewReader(tt.input), tt.opts)
+			if tt.wantErr != "" {
+				var csvErr *CSVError
+				if !errors.As(err, &csvErr) {
+					t.Fatalf("error = %v, want *CSVError", err)
+				}
+				if csvErr.Message != tt.wantErr {
+					t.Errorf("message = %q, want %q", csvErr.Message, tt.wantErr)
+				}
+				var rows []int
+				for _, row := range csvErr.Rows {
+					rows = append(rows, row.Row)
+				}
+				if fmt.Sprint(rows) != fmt.Sprint(tt.wantRows) {
+					t.Errorf("rows = %v, want %v", rows, tt.wantRows)
+				}
+				if tt.wantDelimiter != "" && csvErr.Delimiter != tt.wantDelimiter {
+					t.Errorf("delimiter = %q, want %q", csvErr.Delimiter, tt.wantDelimiter)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			var got []string
+			for _, p := range series {
+				got = append(got, fmt.Sprintf("%s=%g", p.Date.Format(dateLayout), p.Value))
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("series = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCSVTooLarge(t *testing.T) {
+	input := strings.NewReader("date,value\n" + strings.Repeat("2025-01-01,1\n", MaxUploadBytes/12+1))
+	if _, err := ParseCSV(input); !errors.Is(err, ErrFileTooLarge) {
+		t.Fatalf("error = %v, want ErrFileTooLarge", err)
+	}
+}
@@ -0,0 +1,186 @@
+package forecasting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Batas jumlah hari yang boleh diprediksi (periods) dari API
+const (
+	DefaultPeriods = 30
+	MaxPeriods     = 365
+)
+
+// Model yang bisa dipilih lewat parameter `model`
+const (
+	ModelAuto          = "auto"           // ML service (Prophet), fallback ke native kalau ML down
+	ModelProphet       = "prophet"        // ML service saja, tanpa fallback
+	ModelHoltWinters   = "holt_winters"   // Native Go, Holt-Winters additive (seasonality mingguan)
+	ModelMovingAverage = "moving_average" // Native Go, moving average sederhana
+)
+
+var (
+	ErrInsufficientData = errors.New("insufficient data")
+	ErrUnknownModel     = errors.New("unknown model")
+	ErrInvalidPeriods   = errors.New("invalid periods")
+)
+
+// Point satu titik data harian (tanggal + nilai, mis. quantity terjual)
+type Point struct {
+	Date  time.Time
+	Value float64
+}
+
+// Request input forecast: series harian terurut + jumlah hari yang mau diprediksi (+ holiday sebagai regressor, optional)
+type Request struct {
+	Series   []Point
+	Periods  int
+	Holidays []Holiday
+}
+
+// validate cek request sebelum diproses engine (periods <= 0 bikin slice forecast negatif)
+func (r Request) validate() error {
+	if r.Periods < 1 {
+		return fmt.Errorf("%w: must be at least 1, got %d", ErrInvalidPeriods, r.Periods)
+	}
+	return nil
+}
+
+// ValidatePeriods cek periods dari input user: 1 sampai MaxPeriods
+func ValidatePeriods(periods int) error {
+	if periods < 1 || periods > MaxPeriods {
+		return fmt.Errorf("%w: must be between 1 and %d", ErrInvalidPeriods, MaxPeriods)
+	}
+	return nil
+}
+
+// Result hasil forecast: titik historical (actual + fitted) dan titik prediksi ke depan
+type Result struct {
+	Model          string
+	FallbackReason string
+	Historical     []ResultPoint
+	Forecast       []ResultPoint
+}
+
+// ResultPoint satu titik historical/forecast. Field yang gak tersedia dari model yang dipakai
+// (mis. komponen yearly di engine native) di-omit dari JSON.
+type ResultPoint struct {
+	Date      string   `json:"date" example:"2025-01-31"`           // YYYY-MM-DD
+	Actual    *float64 `json:"actual,omitempty" example:"12"`       // Penjualan aktual (historical saja)
+	Yhat      *float64 `json:"yhat,omitempty" example:"11.4"`       // Nilai prediksi / fitted
+	YhatLower *float64 `json:"yhat_lower,omitempty" example:"8.2"`  // Batas bawah interval kepercayaan 95%
+	YhatUpper *float64 `json:"yhat_upper,omitempty" example:"14.6"` // Batas atas interval kepercayaan 95%
+	Trend     *float64 `json:"trend,omitempty" example:"10.9"`      // Komponen trend
+	Weekly    *float64 `json:"weekly,omitempty" example:"0.5"`      // Komponen musiman mingguan
+	Yearly    *float64 `json:"yearly,omitempty" example:"-0.1"`     // Komponen musiman tahunan (Prophet)
+	Holidays  *float64 `json:"holidays,omitempty" example:"3.2"`    // Efek holiday/event di tanggal ini
+}
+
+// Time tanggal titik sebagai time.Time (UTC)
+func (p ResultPoint) Time() (time.Time, error) {
+	return time.Parse(dateLayout, p.Date)
+}
+
+// Forecaster interface bersama untuk ML service & engine native Go
+type Forecaster interface {
+	Name() string
+	Forecast(ctx context.Context, req Request) (*Result, error)
+}
+
+// New pilih forecaster berdasarkan nama model. ml boleh nil kalau cuma pakai model native.
+func New(model string, ml Forecaster) (Forecaster, error) {
+	switch model {
+	case "", ModelAuto:
+		return WithFallback(ml, Native()), nil
+	case ModelProphet:
+		if ml == nil {
+			return nil, fmt.Errorf("%w %q (ML service not configured)", ErrUnknownModel, model)
+		}
+		return ml, nil
+	case ModelHoltWinters:
+		return NewHoltWinters(), nil
+	case ModelMovingAverage:
+		return NewMovingAverage(), nil
+	default:
+		return nil, fmt.Errorf("%w %q (use auto, prophet, holt_winters or moving_average)", ErrUnknownModel, model)
+	}
+}
+
+// Native pilih engine native sesuai panjang data: Holt-Winters butuh minimal 2 minggu, sisanya moving average
+func Native() Forecaster {
+	return nativeAuto{}
+}
+
+type nativeAuto struct{}
+
+func (nativeAuto) Name() string { return ModelHoltWinters }
+
+func (nativeAuto) Forecast(ctx context.Context, req Request) (*Result, error) {
+	hw := NewHoltWinters()
+	if len(req.Series) >= 2*hw.SeasonLength {
+		return hw.Forecast(ctx, req)
+	}
+	return NewMovingAverage().Forecast(ctx, req)
+}
+
+// fallbackForecaster pakai primary dulu, kalau primary unavailable (unreachable / 5xx) pindah ke fallback
+type fallbackForecaster struct {
+	primary  Forecaster
+	fallback Forecaster
+}
+
+// WithFallback bungkus primary dengan fallback otomatis
+func WithFallback(primary, fallback Forecaster) Forecaster {
+	if primary == nil {
+		return fallback
+	}
+	return fallbackForecaster{primary: primary, fallback: fallback}
+}
+
+func (f fallbackForecaster) Name() string { return f.primary.Name() }
+
+func (f fallbackForecaster) Forecast(ctx context.Context, req Request) (*Result, error) {
+	result, err := f.primary.Forecast(ctx, req)
+	if err == nil || !IsUnavailable(err) {
+		return result, err
+	}
+	result, fallbackErr := f.fallback.Forecast(ctx, req)
+	if fallbackErr != nil {
+		return nil, fallbackErr
+	}
+	result.FallbackReason = err.Error()
+	return result, nil
+}
+
+// forecastDates tanggal-tanggal setelah titik terakhir series
+func forecastDates(series []Point, periods int) []time.Time {
+	if periods <= 0 {
+		return nil
+	}
+	last := series[len(series)-1].Date
+	dates := make([]time.Time, periods)
+	for i := range dates {
+		dates[i] = last.AddDate(0, 0, i+1)
+	}
+	return dates
+}
+
+func historicalPoint(p Point, fitted *float64) ResultPoint {
+	actual := p.Value
+	return ResultPoint{Date: p.Date.Format(dateLayout), Actual: &actual, Yhat: fitted}
+}
+
+func forecastPoint(date time.Time, yhat, lower, upper float64) ResultPoint {
+	return ResultPoint{Date: date.Format(dateLayout), Yhat: &yhat, YhatLower: &lower, YhatUpper: &upper}
+}
+
+func nonNegative(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	return v
+}
@@ -0,0 +1,98 @@
+package forecasting
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// weeklySeries n hari mulai 2025-01-06 (Senin) dengan pola mingguan + trend naik
+func weeklySeries(n int) []Point {
+	start := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	pattern := []float64{10, 12, 11, 13, 20, 25, 8}
+	series := make([]Point, n)
+	for i := range series {
+		series[i] = Point{Date: start.AddDate(0, 0, i), Value: pattern[i%7] + float64(i)*0.1}
+	}
+	return series
+}
+
+func TestValidatePeriods(t *testing.T) {
+	tests := []struct {
+		periods int
+		wantErr bool
+	}{
+		{-1, true},
+		{0, true},
+		{1, false},
+		{DefaultPeriods, false},
+		{MaxPeriods, false},
+		{MaxPeriods + 1, true},
+	}
+	for _, tt := range tests {
+		err := ValidatePeriods(tt.periods)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidatePeriods(%d) error = %v, wantErr %v", tt.periods, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidPeriods) {
+			t.Errorf("ValidatePeriods(%d) error = %v, want ErrInvalidPeriods", tt.periods, err)
+		}
+	}
+}
+
+func TestNativeForecasters(t *testing.T) {
+	tests := []struct {
+		name       string
+		forecaster Forecaster
+		points     int
+		periods    int
+		wantErr    error
+		wantModel  string
+	}{
+		{"holt-winters", NewHoltWinters(), 28, 14, nil, ModelHoltWinters},
+		{"holt-winters needs two seasons", NewHoltWinters(), 13, 7, ErrInsufficientData, ""},
+		{"holt-winters zero periods", NewHoltWinters(), 28, 0, ErrInvalidPeriods, ""},
+		{"holt-winters negative periods", NewHoltWinters(), 28, -1, ErrInvalidPeriods, ""},
+		{"moving average", NewMovingAverage(), 5, 3, nil, ModelMovingAverage},
+		{"moving average negative periods", NewMovingAverage(), 5, -1, ErrInvalidPeriods, ""},
+		{"native picks holt-winters", Native(), 28, 7, nil, ModelHoltWinters},
+		{"native falls back to moving average", Native(), 10, 7, nil, ModelMovingAverage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series := weeklySeries(tt.points)
+			result, err := tt.forecaster.Forecast(context.Background(), Request{Series: series, Periods: tt.periods})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if result.Model != tt.wantModel {
+				t.Errorf("model = %q, want %q", result.Model, tt.wantModel)
+			}
+			if len(result.Historical) != len(series) {
+				t.Errorf("historical points = %d, want %d", len(result.Historical), len(series))
+			}
+			if len(result.Forecast) != tt.periods {
+				t.Fatalf("forecast points = %d, want %d", len(result.Forecast), tt.periods)
+			}
+			next := series[len(series)-1].Date.AddDate(0, 0, 1).Format(dateLayout)
+			if result.Forecast[0].Date != next {
+				t.Errorf("first forecast date = %s, want %s", result.Forecast[0].Date, next)
+			}
+			for _, p := range result.Forecast {
+				if p.Yhat == nil || *p.Yhat < 0 {
+					t.Fatalf("forecast %s: yhat = %v, want non-negative value", p.Date, p.Yhat)
+				}
+				if p.YhatLower != nil && p.YhatUpper != nil && (*p.YhatLower > *p.Yhat || *p.Yhat > *p.YhatUpper) {
+					t.Errorf("forecast %s: interval [%g, %g] does not contain yhat %g", p.Date, *p.YhatLower, *p.YhatUpper, *p.Yhat)
+				}
+			}
+		})
+	}
+}
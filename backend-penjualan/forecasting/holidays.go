@@ -0,0 +1,102 @@
+package forecasting
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Holiday libur / event sebagai regressor. Window: efek dari Date+LowerWindow sampai Date+UpperWindow.
+type Holiday struct {
+	Name        string
+	Date        time.Time
+	LowerWindow int // <= 0
+	UpperWindow int // >= 0
+}
+
+// holidayDays map tanggal (YYYY-MM-DD) -> nama holiday yang window-nya mencakup tanggal itu
+func holidayDays(holidays []Holiday) map[string][]string {
+	days := make(map[string][]string)
+	for _, h := range holidays {
+		for offset := h.LowerWindow; offset <= h.UpperWindow; offset++ {
+			key := h.Date.AddDate(0, 0, offset).Format(dateLayout)
+			days[key] = appendUnique(days[key], h.Name)
+		}
+	}
+	return days
+}
+
+func appendUnique(names []string, name string) []string {
+	for _, n := range names {
+		if n == name {
+			return names
+		}
+	}
+	return append(names, name)
+}
+
+// applyHolidayEffects regressor sederhana buat engine native: efek tiap holiday = rata-rata residual
+// (actual - fitted) di hari-hari window-nya pada histori, lalu ditambahkan ke titik forecast di window
+// holiday dengan nama yang sama. Holiday yang belum pernah muncul di histori gak punya efek.
+func applyHolidayEffects(req Request, result *Result) {
+	if len(req.Holidays) == 0 {
+		return
+	}
+	days := holidayDays(req.Holidays)
+
+	sums := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, p := range result.Historical {
+		if p.Actual == nil || p.Yhat == nil {
+			continue
+		}
+		for _, name := range days[p.Date] {
+			sums[name] += *p.Actual - *p.Yhat
+			counts[name]++
+		}
+	}
+	if len(counts) == 0 {
+		return
+	}
+
+	for i := range result.Forecast {
+		p := &result.Forecast[i]
+		var effect float64
+		var matched bool
+		for _, name := range days[p.Date] {
+			if counts[name] > 0 {
+				effect += sums[name] / float64(counts[name])
+				matched = true
+			}
+		}
+		if !matched || p.Yhat == nil {
+			continue
+		}
+		yhat := nonNegative(*p.Yhat + effect)
+		p.Yhat = &yhat
+		if p.YhatLower != nil {
+			lower := nonNegative(*p.YhatLower + effect)
+			p.YhatLower = &lower
+		}
+		if p.YhatUpper != nil {
+			upper := nonNegative(*p.YhatUpper + effect)
+			p.YhatUpper = &upper
+		}
+		p.Holidays = &effect
+	}
+}
+
+// holidaysJSON format holidays Prophet (holiday, ds, lower_window, upper_window) buat dikirim ke ML service
+func holidaysJSON(holidays []Holiday) (string, error) {
+	type prophetHoliday struct {
+		Holiday     string `json:"holiday"`
+		DS          string `json:"ds"`
+		LowerWindow int    `json:"lower_window"`
+		UpperWindow int    `json:"upper_window"`
+	}
+	rows := make([]prophetHoliday, len(holidays))
+	for i, h := range holidays {
+		rows[i] = prophetHoliday{Holiday: h.Name, DS: h.Date.Format(dateLayout), LowerWindow: h.LowerWindow, UpperWindow: h.UpperWindow}
+	}
+	data, err := json.Marshal(rows)
+	return string(data), err
+}
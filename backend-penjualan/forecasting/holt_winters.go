@@ -0,0 +1,131 @@
+package forecasting
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// HoltWinters triple exponential smoothing (additive) dengan seasonality mingguan.
+// Alpha/Beta/Gamma dipilih lewat grid search kecil (SSE one-step-ahead terkecil).
+type HoltWinters struct {
+	SeasonLength int
+}
+
+func NewHoltWinters() *HoltWinters {
+	return &HoltWinters{SeasonLength: 7}
+}
+
+func (hw *HoltWinters) Name() string { return ModelHoltWinters }
+
+var (
+	hwAlphas = []float64{0.1, 0.3, 0.5, 0.7}
+	hwBetas  = []float64{0.01, 0.05, 0.1, 0.2}
+	hwGammas = []float64{0.05, 0.1, 0.2, 0.3}
+)
+
+// hwFit state akhir + fitted values untuk satu kombinasi parameter
+type hwFit struct {
+	level, trend float64
+	seasonal     []float64
+	fitted       []float64
+	fittedTrend  []float64 // level+trend dari fitted value (komponen trend)
+	fittedSeason []float64 // komponen musiman dari fitted value
+	sse          float64
+}
+
+func (hw *HoltWinters) fit(values []float64, alpha, beta, gamma float64) hwFit {
+	L := hw.SeasonLength
+	first := avg(values[:L])
+	second := avg(values[L : 2*L])
+
+	f := hwFit{level: first, trend: (second - first) / float64(L), seasonal: make([]float64, L)}
+	for i := 0; i < L; i++ {
+		f.seasonal[i] = values[i] - first
+	}
+	f.fitted = make([]float64, len(values))
+	f.fittedTrend = make([]float64, len(values))
+	f.fittedSeason = make([]float64, len(values))
+	copy(f.fitted[:L], values[:L])
+
+	for t := L; t < len(values); t++ {
+		s := f.seasonal[t%L]
+		predicted := f.level + f.trend + s
+		f.fitted[t] = predicted
+		f.fittedTrend[t] = f.level + f.trend
+		f.fittedSeason[t] = s
+		f.sse += (values[t] - predicted) * (values[t] - predicted)
+
+		prevLevel := f.level
+		f.level = alpha*(values[t]-s) + (1-alpha)*(f.level+f.trend)
+		f.trend = beta*(f.level-prevLevel) + (1-beta)*f.trend
+		f.seasonal[t%L] = gamma*(values[t]-f.level) + (1-gamma)*s
+	}
+	return f
+}
+
+func (hw *HoltWinters) Forecast(ctx context.Context, req Request) (*Result, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	L := hw.SeasonLength
+	n := len(req.Series)
+	if n < 2*L {
+		return nil, fmt.Errorf("%w: holt-winters needs at least %d points, got %d", ErrInsufficientData, 2*L, n)
+	}
+
+	values := make([]float64, n)
+	for i, p := range req.Series {
+		values[i] = p.Value
+	}
+
+	var best hwFit
+	bestAlpha := 0.0
+	for _, alpha := range hwAlphas {
+		for _, beta := range hwBetas {
+			for _, gamma := range hwGammas {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+				f := hw.fit(values, alpha, beta, gamma)
+				if bestAlpha == 0 || f.sse < best.sse {
+					best, bestAlpha = f, alpha
+				}
+			}
+		}
+	}
+
+	result := &Result{Model: hw.Name()}
+	for i, p := range req.Series {
+		if i < L {
+			result.Historical = append(result.Historical, historicalPoint(p, nil))
+			continue
+		}
+		fitted, trend, weekly := best.fitted[i], best.fittedTrend[i], best.fittedSeason[i]
+		point := historicalPoint(p, &fitted)
+		point.Trend, point.Weekly = &trend, &weekly
+		result.Historical = append(result.Historical, point)
+	}
+
+	sd := math.Sqrt(best.sse / float64(n-L))
+	for h, date := range forecastDates(req.Series, req.Periods) {
+		step := float64(h + 1)
+		trend, weekly := best.level+step*best.trend, best.seasonal[(n+h)%L]
+		yhat := trend + weekly
+		// Interval melebar seiring horizon (aproksimasi varians error exponential smoothing)
+		margin := 1.96 * sd * math.Sqrt(1+(step-1)*bestAlpha*bestAlpha)
+		point := forecastPoint(date, nonNegative(yhat), nonNegative(yhat-margin), nonNegative(yhat+margin))
+		point.Trend, point.Weekly = &trend, &weekly
+		result.Forecast = append(result.Forecast, point)
+	}
+	applyHolidayEffects(req, result)
+	return result, nil
+}
+
+func avg(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
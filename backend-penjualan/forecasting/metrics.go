@@ -0,0 +1,42 @@
+package forecasting
+
+import (
+	"fmt"
+	"math"
+)
+
+// Accuracy error forecast dibanding penjualan aktual
+type Accuracy struct {
+	Points int      `json:"points"` // Jumlah hari yang dibandingkan
+	MAPE   *float64 `json:"mape"`   // Mean absolute percentage error (%), hari dengan actual 0 di-skip; null kalau semua 0
+	MAE    float64  `json:"mae"`    // Mean absolute error (unit quantity)
+	RMSE   float64  `json:"rmse"`   // Root mean squared error (unit quantity)
+}
+
+// Evaluate hitung MAPE, MAE & RMSE. actual & predicted harus sama panjang (per hari, urutan sama).
+func Evaluate(actual, predicted []float64) (Accuracy, error) {
+	if len(actual) != len(predicted) {
+		return Accuracy{}, fmt.Errorf("actual and predicted length differ (%d vs %d)", len(actual), len(predicted))
+	}
+	if len(actual) == 0 {
+		return Accuracy{}, fmt.Errorf("%w: nothing to evaluate", ErrInsufficientData)
+	}
+	var absSum, sqSum, pctSum float64
+	var pctCount int
+	for i, a := range actual {
+		diff := a - predicted[i]
+		absSum += math.Abs(diff)
+		sqSum += diff * diff
+		if a != 0 {
+			pctSum += math.Abs(diff / a)
+			pctCount++
+		}
+	}
+	n := float64(len(actual))
+	acc := Accuracy{Points: len(actual), MAE: absSum / n, RMSE: math.Sqrt(sqSum / n)}
+	if pctCount > 0 {
+		mape := pctSum / float64(pctCount) * 100
+		acc.MAPE = &mape
+	}
+	return acc, nil
+}
@@ -0,0 +1,153 @@
+package forecasting
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend-penjualan/mlclient"
+)
+
+// MLServiceError error dari ML service (unreachable, status non-200, circuit open, atau response gak bisa di-parse)
+type MLServiceError = mlclient.Error
+
+// IsUnavailable true kalau ML service gak bisa dihubungi, circuit breaker open, atau error 5xx (layak di-fallback)
+func IsUnavailable(err error) bool {
+	var mlErr *MLServiceError
+	if !errors.As(err, &mlErr) {
+		return false
+	}
+	return mlErr.Status == 0 || mlErr.Status >= http.StatusInternalServerError
+}
+
+// MLService forecaster via FastAPI (Prophet). Series dikirim sebagai CSV date,projected_quantity
+// lewat mlclient (retry, circuit breaker).
+type MLService struct {
+	Client mlclient.Client
+}
+
+func NewMLService(client mlclient.Client) *MLService {
+	return &MLService{Client: client}
+}
+
+func (m *MLService) Name() string { return ModelProphet }
+
+func (m *MLService) Forecast(ctx context.Context, req Request) (*Result, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	csvData, err := SeriesToCSV(req.Series)
+	if err != nil {
+		return nil, err
+	}
+	predictReq := mlclient.PredictRequest{CSV: csvData, Periods: req.Periods}
+	if len(req.Holidays) > 0 {
+		// Holiday dikirim sebagai field form "holidays" (JSON format Prophet)
+		holidays, err := holidaysJSON(req.Holidays)
+		if err != nil {
+			return nil, err
+		}
+		predictReq.Fields = map[string]string{"holidays": holidays}
+	}
+	bodyBytes, err := m.Client.Predict(ctx, predictReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed struct {
+		Historical []mlPoint `json:"historical"`
+		Forecast   []mlPoint `json:"forecast"`
+	}
+	if err := json.Unmarshal(bodyBytes, &parsed); err != nil {
+		return nil, &MLServiceError{Message: "Failed to parse prediction", Details: err.Error(), Raw: string(bodyBytes)}
+	}
+	result := &Result{Model: m.Name()}
+	if result.Historical, err = normalizeMLPoints("historical", parsed.Historical, false); err == nil {
+		result.Forecast, err = normalizeMLPoints("forecast", parsed.Forecast, true)
+	}
+	if err == nil && len(result.Forecast) == 0 && req.Periods > 0 {
+		err = errors.New("forecast is empty")
+	}
+	if err != nil {
+		return nil, &MLServiceError{Message: "Invalid prediction payload", Details: err.Error(), Raw: string(bodyBytes)}
+	}
+	return result, nil
+}
+
+// mlPoint titik dari response ML service (kolom Prophet: ds, y, yhat, trend, weekly, yearly, ...)
+type mlPoint struct {
+	DS        string   `json:"ds"`
+	Date      string   `json:"date"`
+	Y         *float64 `json:"y"`
+	Actual    *float64 `json:"actual"`
+	Yhat      *float64 `json:"yhat"`
+	YhatLower *float64 `json:"yhat_lower"`
+	YhatUpper *float64 `json:"yhat_upper"`
+	Trend     *float64 `json:"trend"`
+	Weekly    *float64 `json:"weekly"`
+	Yearly    *float64 `json:"yearly"`
+	Holidays  *float64 `json:"holidays"`
+}
+
+// normalizeMLPoints validasi titik dari ML service & ubah ke ResultPoint.
+// Tanggal wajib valid (YYYY-MM-DD, boleh ada jam), forecast wajib punya yhat, yhat_lower <= yhat_upper.
+func normalizeMLPoints(field string, raw []mlPoint, requireYhat bool) ([]ResultPoint, error) {
+	points := make([]ResultPoint, 0, len(raw))
+	for i, p := range raw {
+		dateStr := p.Date
+		if dateStr == "" {
+			dateStr = p.DS
+		}
+		if len(dateStr) < len(dateLayout) {
+			return nil, fmt.Errorf("%s[%d]: missing or invalid date %q", field, i, dateStr)
+		}
+		date, err := time.Parse(dateLayout, dateStr[:len(dateLayout)])
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d]: invalid date %q", field, i, dateStr)
+		}
+		if requireYhat && p.Yhat == nil {
+			return nil, fmt.Errorf("%s[%d]: missing yhat", field, i)
+		}
+		if p.YhatLower != nil && p.YhatUpper != nil && *p.YhatLower > *p.YhatUpper {
+			return nil, fmt.Errorf("%s[%d]: yhat_lower greater than yhat_upper", field, i)
+		}
+		actual := p.Actual
+		if actual == nil {
+			actual = p.Y
+		}
+		points = append(points, ResultPoint{
+			Date:      date.Format(dateLayout),
+			Actual:    actual,
+			Yhat:      p.Yhat,
+			YhatLower: p.YhatLower,
+			YhatUpper: p.YhatUpper,
+			Trend:     p.Trend,
+			Weekly:    p.Weekly,
+			Yearly:    p.Yearly,
+			Holidays:  p.Holidays,
+		})
+	}
+	return points, nil
+}
+
+// SeriesToCSV bikin CSV date,projected_quantity (format yang diterima ML service)
+func SeriesToCSV(series []Point) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	if err := w.Write([]string{"date", "projected_quantity"}); err != nil {
+		return nil, err
+	}
+	for _, p := range series {
+		if err := w.Write([]string{p.Date.Format(dateLayout), strconv.FormatFloat(p.Value, 'f', -1, 64)}); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
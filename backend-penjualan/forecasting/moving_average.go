@@ -0,0 +1,71 @@
+package forecasting
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// MovingAverage forecast flat = rata-rata `Window` hari terakhir, interval dari std dev residual
+type MovingAverage struct {
+	Window int
+}
+
+func NewMovingAverage() *MovingAverage {
+	return &MovingAverage{Window: 7}
+}
+
+func (m *MovingAverage) Name() string { return ModelMovingAverage }
+
+func (m *MovingAverage) Forecast(ctx context.Context, req Request) (*Result, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	n := len(req.Series)
+	if n < 2 {
+		return nil, fmt.Errorf("%w: moving average needs at least 2 points, got %d", ErrInsufficientData, n)
+	}
+	window := m.Window
+	if window > n {
+		window = n
+	}
+
+	// Fitted value tiap hari = rata-rata window hari sebelumnya
+	result := &Result{Model: m.Name()}
+	var sumSq float64
+	var residuals int
+	for i, p := range req.Series {
+		if i < window {
+			result.Historical = append(result.Historical, historicalPoint(p, nil))
+			continue
+		}
+		fitted := mean(req.Series[i-window : i])
+		sumSq += (p.Value - fitted) * (p.Value - fitted)
+		residuals++
+		result.Historical = append(result.Historical, historicalPoint(p, &fitted))
+	}
+	sd := 0.0
+	if residuals > 0 {
+		sd = math.Sqrt(sumSq / float64(residuals))
+	}
+
+	level := mean(req.Series[n-window:])
+	for h, date := range forecastDates(req.Series, req.Periods) {
+		margin := 1.96 * sd * math.Sqrt(float64(h+1))
+		result.Forecast = append(result.Forecast,
+			forecastPoint(date, nonNegative(level), nonNegative(level-margin), nonNegative(level+margin)))
+	}
+	applyHolidayEffects(req, result)
+	return result, nil
+}
+
+func mean(points []Point) float64 {
+	if len(points) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, p := range points {
+		sum += p.Value
+	}
+	return sum / float64(len(points))
+}
@@ -0,0 +1,29 @@
+package forecasting
+
+import (
+	"strconv"
+	"strings"
+)
+
+// InputPoint satu titik series dari body JSON
+type InputPoint struct {
+	Date  string   `json:"date" example:"2025-01-01"`
+	Value *float64 `json:"value" example:"12"`
+}
+
+// ParsePoints validasi titik dari body JSON (tanggal YYYY-MM-DD unik, value angka >= 0) jadi series terurut.
+// Error per titik dilaporkan dengan Row = urutan point (mulai 1).
+func ParsePoints(points []InputPoint) ([]Point, error) {
+	if len(points) == 0 {
+		return nil, &CSVError{Message: "points must not be empty"}
+	}
+	v := newRowValidator("points has invalid entries")
+	for i, p := range points {
+		if p.Value == nil {
+			v.addError(RowError{Row: i + 1, Column: "value", Message: "value is required"})
+			continue
+		}
+		v.add(i+1, "date", strings.TrimSpace(p.Date), "value", strconv.FormatFloat(*p.Value, 'f', -1, 64), false)
+	}
+	return v.result()
+}
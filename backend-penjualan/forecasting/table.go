@@ -0,0 +1,252 @@
+package forecasting
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	MaxUploadBytes  = 5 << 20 // Batas ukuran file upload (CSV/XLSX, 5 MB)
+	maxCSVRowErrors = 100     // Batas jumlah error per baris yang dilaporkan
+)
+
+var ErrFileTooLarge = fmt.Errorf("file too large (max %d MB)", MaxUploadBytes>>20)
+
+// RowError satu masalah di baris input (CSV/XLSX: Row 1 = header; JSON: Row = index point mulai 1)
+type RowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Value   string `json:"value,omitempty"`
+	Message string `json:"message"`
+}
+
+// CSVError input (CSV, XLSX atau JSON points) gak valid; Rows berisi laporan per baris
+// (kosong kalau masalahnya di file/header)
+type CSVError struct {
+	Message   string     `json:"error"`
+	Delimiter string     `json:"delimiter,omitempty"`
+	Sheet     string     `json:"sheet,omitempty"`
+	Rows      []RowError `json:"rows,omitempty"`
+	Truncated bool       `json:"truncated,omitempty"` // true kalau error lebih dari yang dilaporkan
+}
+
+func (e *CSVError) Error() string {
+	if len(e.Rows) == 0 {
+		return e.Message
+	}
+	first := e.Rows[0]
+	return fmt.Sprintf("%s (row %d: %s)", e.Message, first.Row, first.Message)
+}
+
+// IsCSVError cek apakah err berasal dari validasi input
+func IsCSVError(err error) bool {
+	var csvErr *CSVError
+	return errors.As(err, &csvErr)
+}
+
+// TableOptions pilihan kolom date/value: nama header (case-insensitive) atau huruf kolom ("A", "B", ...).
+// Kosong = deteksi otomatis (date + projected_quantity/value).
+type TableOptions struct {
+	DateColumn  string
+	ValueColumn string
+}
+
+// parseTable validasi baris tabel (baris pertama header) jadi series terurut.
+// excelDates: tanggal boleh berupa serial number Excel.
+func parseTable(rows [][]string, opts TableOptions, excelDates bool) ([]Point, error) {
+	if len(rows) == 0 {
+		return nil, &CSVError{Message: "file has no rows"}
+	}
+	dateCol, valueCol, hasHeader, err := resolveColumns(rows[0], opts)
+	if err != nil {
+		return nil, err
+	}
+	header := []string{"date", "value"}
+	start := 0
+	if hasHeader {
+		header = rows[0]
+		start = 1
+	}
+	if len(rows) <= start {
+		return nil, &CSVError{Message: "file must have a header and at least one data row"}
+	}
+
+	v := newRowValidator("file has invalid rows")
+	for i, row := range rows[start:] {
+		rowNum := i + start + 1
+		if isBlankRow(row) {
+			continue // Baris kosong di tengah/akhir sheet
+		}
+		if !hasHeader && len(row) != len(header) {
+			v.addError(RowError{Row: rowNum, Message: fmt.Sprintf("expected %d columns, got %d", len(header), len(row))})
+			continue
+		}
+		if hasHeader && len(row) != len(header) && !excelDates {
+			v.addError(RowError{Row: rowNum, Message: fmt.Sprintf("expected %d columns, got %d", len(header), len(row))})
+			continue
+		}
+		v.add(rowNum, header[dateCol], cell(row, dateCol), header[valueCol], cell(row, valueCol), excelDates)
+	}
+	return v.result()
+}
+
+func cell(row []string, col int) string {
+	if col < len(row) {
+		return strings.TrimSpace(row[col])
+	}
+	return ""
+}
+
+func isBlankRow(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveColumns cari kolom date & value (dari opsi atau header). Kalau gak ada opsi dan baris pertama
+// ternyata data (2 kolom: tanggal, angka), anggap file tanpa header.
+func resolveColumns(first []string, opts TableOptions) (dateCol, valueCol int, hasHeader bool, err error) {
+	dateCol, valueCol = -1, -1
+	if opts.DateColumn != "" || opts.ValueColumn != "" {
+		dateCol = findColumn(first, opts.DateColumn, "date")
+		valueCol = findColumn(first, opts.ValueColumn, "projected_quantity", "value")
+		if dateCol == -1 || valueCol == -1 {
+			return -1, -1, true, &CSVError{
+				Message: fmt.Sprintf("column not found (date_column %q, value_column %q)", opts.DateColumn, opts.ValueColumn),
+				Rows:    []RowError{{Row: 1, Value: strings.Join(first, ", "), Message: "header not recognized"}},
+			}
+		}
+		return dateCol, valueCol, true, nil
+	}
+
+	dateCol = findColumn(first, "", "date")
+	valueCol = findColumn(first, "", "projected_quantity", "value")
+	if dateCol != -1 && valueCol != -1 {
+		return dateCol, valueCol, true, nil
+	}
+	if dateCol == -1 && valueCol == -1 && len(first) == 2 {
+		if _, err := time.Parse(dateLayout, strings.TrimSpace(first[0])); err == nil {
+			return 0, 1, false, nil
+		}
+	}
+	return -1, -1, true, &CSVError{
+		Message: "file must have 'date' and 'projected_quantity' (or 'value') columns",
+		Rows:    []RowError{{Row: 1, Value: strings.Join(first, ", "), Message: "header not recognized"}},
+	}
+}
+
+// findColumn cari index kolom: pakai name (header atau huruf kolom) kalau diisi, kalau gak pakai defaults
+// berurutan (yang pertama ketemu menang)
+func findColumn(header []string, name string, defaults ...string) int {
+	if name != "" {
+		for i, h := range header {
+			if strings.EqualFold(strings.TrimSpace(h), strings.TrimSpace(name)) {
+				return i
+			}
+		}
+		if idx, err := excelize.ColumnNameToNumber(strings.ToUpper(strings.TrimSpace(name))); err == nil && idx <= len(header) {
+			return idx - 1
+		}
+		return -1
+	}
+	for _, want := range defaults {
+		for i, h := range header {
+			if strings.EqualFold(strings.TrimSpace(h), want) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// rowValidator validasi & kumpulkan titik (tanggal valid & unik, value angka >= 0) plus error per baris
+type rowValidator struct {
+	err    *CSVError
+	seen   map[string]int
+	series []Point
+}
+
+func newRowValidator(message string) *rowValidator {
+	return &rowValidator{err: &CSVError{Message: message}, seen: make(map[string]int)}
+}
+
+func (v *rowValidator) addError(rowErr RowError) {
+	if len(v.err.Rows) >= maxCSVRowErrors {
+		v.err.Truncated = true
+		return
+	}
+	v.err.Rows = append(v.err.Rows, rowErr)
+}
+
+func (v *rowValidator) add(rowNum int, dateColumn, dateStr, valueColumn, valueStr string, excelDates bool) {
+	valid := true
+	date, err := parseDate(dateStr, excelDates)
+	if err != nil {
+		v.addError(RowError{Row: rowNum, Column: dateColumn, Value: dateStr, Message: "invalid date (use YYYY-MM-DD)"})
+		valid = false
+	} else if firstRow, dup := v.seen[date.Format(dateLayout)]; dup {
+		v.addError(RowError{Row: rowNum, Column: dateColumn, Value: dateStr, Message: fmt.Sprintf("duplicate date (first seen in row %d)", firstRow)})
+		valid = false
+	} else {
+		v.seen[date.Format(dateLayout)] = rowNum
+	}
+
+	value, err := strconv.ParseFloat(valueStr, 64)
+	switch {
+	case err != nil || math.IsNaN(value) || math.IsInf(value, 0):
+		v.addError(RowError{Row: rowNum, Column: valueColumn, Value: valueStr, Message: "value must be a number"})
+		valid = false
+	case value < 0:
+		v.addError(RowError{Row: rowNum, Column: valueColumn, Value: valueStr, Message: "value must not be negative"})
+		valid = false
+	}
+
+	if valid {
+		v.series = append(v.series, Point{Date: date, Value: value})
+	}
+}
+
+func (v *rowValidator) result() ([]Point, error) {
+	if len(v.err.Rows) > 0 {
+		return nil, v.err
+	}
+	if len(v.series) == 0 {
+		return nil, &CSVError{Message: "file has no data rows"}
+	}
+	sort.Slice(v.series, func(i, j int) bool { return v.series[i].Date.Before(v.series[j].Date) })
+	return v.series, nil
+}
+
+// parseDate YYYY-MM-DD; dari Excel juga terima serial number tanggal & datetime "YYYY-MM-DD hh:mm:ss"
+func parseDate(s string, excelDates bool) (time.Time, error) {
+	if date, err := time.Parse(dateLayout, s); err == nil {
+		return date, nil
+	}
+	if !excelDates {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	if len(s) > len(dateLayout) {
+		if date, err := time.Parse(dateLayout, s[:len(dateLayout)]); err == nil {
+			return date, nil
+		}
+	}
+	serial, err := strconv.ParseFloat(s, 64)
+	if err != nil || serial <= 0 {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	t, err := excelize.ExcelDateToTime(serial, false)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+}
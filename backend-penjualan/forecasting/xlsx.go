@@ -0,0 +1,52 @@
+package forecasting
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// XLSXOptions pilihan sheet & kolom buat ParseXLSX. Sheet kosong = sheet pertama.
+type XLSXOptions struct {
+	Sheet string
+	TableOptions
+}
+
+// ParseXLSX baca sheet di file .xlsx jadi series harian dengan aturan validasi yang sama dengan CSV.
+// Tanggal boleh berupa sel tanggal Excel (serial number) atau teks YYYY-MM-DD.
+func ParseXLSX(r io.Reader, opts XLSXOptions) ([]Point, error) {
+	data, err := readLimited(r)
+	if err != nil {
+		return nil, err
+	}
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, &CSVError{Message: fmt.Sprintf("invalid XLSX file: %v", err)}
+	}
+	defer f.Close()
+
+	sheet := opts.Sheet
+	if sheet == "" {
+		sheet = f.GetSheetName(0)
+	} else if idx, err := f.GetSheetIndex(sheet); err != nil || idx == -1 {
+		return nil, &CSVError{Message: fmt.Sprintf("sheet %q not found (available: %v)", sheet, f.GetSheetList()), Sheet: sheet}
+	}
+
+	// RawCellValue biar sel tanggal kebaca sebagai serial number, bukan hasil format tampilan
+	rows, err := f.GetRows(sheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, &CSVError{Message: fmt.Sprintf("failed to read sheet: %v", err), Sheet: sheet}
+	}
+	if len(rows) == 0 {
+		return nil, &CSVError{Message: fmt.Sprintf("sheet %q is empty", sheet), Sheet: sheet}
+	}
+	series, err := parseTable(rows, opts.TableOptions, true)
+	var csvErr *CSVError
+	if errors.As(err, &csvErr) {
+		csvErr.Sheet = sheet
+	}
+	return series, err
+}
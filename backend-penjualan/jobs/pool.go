@@ -0,0 +1,279 @@
+// Package jobs worker pool buat forecast async. Antrian & state job disimpan di tabel
+// forecast_jobs (Postgres), jadi job yang belum selesai tetap diproses setelah server restart.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+
+	"backend-penjualan/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultWorkers      = 2
+	defaultJobTimeout   = 5 * time.Minute
+	defaultPollInterval = 5 * time.Second
+	defaultMaxAttempts  = 3
+)
+
+// Handler proses satu job, return ID forecast run yang tersimpan (kalau ada)
+type Handler func(ctx context.Context, job *models.ForecastJob) (*uint, error)
+
+// Pool sejumlah worker yang ambil job queued dari DB (SELECT ... FOR UPDATE SKIP LOCKED),
+// jadi aman juga kalau ada beberapa instance server
+type Pool struct {
+	DB           *gorm.DB
+	Workers      int           // FORECAST_WORKERS, default 2
+	Timeout      time.Duration // FORECAST_JOB_TIMEOUT, default 5m (batas waktu satu job)
+	PollInterval time.Duration // Cek antrian berkala (buat job dari instance lain / requeue)
+	MaxAttempts  int           // Job yang nyangkut (server mati di tengah jalan) dicoba ulang maksimal segini
+
+	handler Handler
+	wake    chan struct{}
+	wg      sync.WaitGroup // Worker & reaper yang masih jalan
+}
+
+// NewPool buat pool dengan konfigurasi dari env
+func NewPool(db *gorm.DB, handler Handler) *Pool {
+	workers := defaultWorkers
+	if n, err := strconv.Atoi(os.Getenv("FORECAST_WORKERS")); err == nil && n > 0 {
+		workers = n
+	}
+	timeout := defaultJobTimeout
+	if d, err := time.ParseDuration(os.Getenv("FORECAST_JOB_TIMEOUT")); err == nil && d > 0 {
+		timeout = d
+	}
+	return &Pool{
+		DB:           db,
+		Workers:      workers,
+		Timeout:      timeout,
+		PollInterval: defaultPollInterval,
+		MaxAttempts:  defaultMaxAttempts,
+		handler:      handler,
+		wake:         make(chan struct{}, workers),
+	}
+}
+
+// Start jalankan worker & reaper di background sampai ctx selesai
+func (p *Pool) Start(ctx context.Context) {
+	// Job "running" dari proses sebelumnya yang sudah lewat timeout dikembalikan ke antrian
+	p.requeueStale()
+	p.wg.Add(p.Workers + 1)
+	for i := 0; i < p.Workers; i++ {
+		go func() {
+			defer p.wg.Done()
+			p.work(ctx)
+		}()
+	}
+	go func() {
+		defer p.wg.Done()
+		p.reap(ctx)
+	}()
+	log.Printf("Forecast job pool started (%d worker(s), timeout %s)", p.Workers, p.Timeout)
+}
+
+// Wait tunggu semua worker berhenti setelah ctx Start selesai (job yang lagi jalan sudah dikembalikan
+// ke antrian). Return error kalau waitCtx habis duluan.
+func (p *Pool) Wait(waitCtx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-waitCtx.Done():
+		return waitCtx.Err()
+	}
+}
+
+// Enqueue simpan job baru dengan status queued & bangunkan worker
+func (p *Pool) Enqueue(job *models.ForecastJob) error {
+	job.Status = models.ForecastJobQueued
+	if err := p.DB.Create(job).Error; err != nil {
+		return err
+	}
+	p.notify()
+	return nil
+}
+
+func (p *Pool) notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default: // Worker sudah dibangunkan, cukup
+	}
+}
+
+func (p *Pool) work(ctx context.Context) {
+	ticker := time.NewTicker(p.PollInterval)
+	defer ticker.Stop()
+	for {
+		// Habiskan antrian dulu, baru tidur
+		for ctx.Err() == nil {
+			job, err := p.claim()
+			if errors.Is(err, errAttemptsExhausted) {
+				continue // Sudah ditandai failed, ambil job berikutnya
+			}
+			if err != nil {
+				log.Printf("Warning: failed to claim forecast job: %v", err)
+				break
+			}
+			if job == nil {
+				break
+			}
+			p.run(ctx, job)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// errAttemptsExhausted job queued yang sudah dicoba MaxAttempts kali (mis. dikembalikan ke antrian waktu shutdown berulang)
+var errAttemptsExhausted = errors.New("forecast job attempts exhausted")
+
+// claim ambil job queued tertua & tandai running (nil kalau antrian kosong).
+// Job yang attempts-nya sudah habis langsung ditandai failed (errAttemptsExhausted) biar gak diulang terus.
+func (p *Pool) claim() (*models.ForecastJob, error) {
+	var job models.ForecastJob
+	exhausted := false
+	err := p.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", models.ForecastJobQueued).Order("id").First(&job).Error; err != nil {
+			return err
+		}
+		now := time.Now()
+		if job.Attempts >= p.MaxAttempts {
+			if err := tx.Model(&job).Updates(map[string]interface{}{
+				"status":      models.ForecastJobFailed,
+				"error":       fmt.Sprintf("Job did not finish after %d attempt(s)", p.MaxAttempts),
+				"finished_at": now,
+			}).Error; err != nil {
+				return err
+			}
+			exhausted = true // Return nil biar update failed-nya ke-commit
+			return nil
+		}
+		job.Status = models.ForecastJobRunning
+		job.Attempts++
+		job.StartedAt = &now
+		return tx.Model(&job).Updates(map[string]interface{}{
+			"status":     job.Status,
+			"attempts":   job.Attempts,
+			"started_at": now,
+		}).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if exhausted {
+		return nil, errAttemptsExhausted
+	}
+	return &job, nil
+}
+
+func (p *Pool) run(ctx context.Context, job *models.ForecastJob) {
+	// Panic di handler jangan sampai matikan seluruh proses (worker di luar Recovery gin)
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Forecast job %d panicked: %v\n%s", job.ID, r, debug.Stack())
+			p.finish(job, map[string]interface{}{
+				"status":      models.ForecastJobFailed,
+				"error":       fmt.Sprintf("Job panicked: %v", r),
+				"finished_at": time.Now(),
+			})
+		}
+	}()
+
+	jobCtx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+	runID, err := p.handler(jobCtx, job)
+
+	if ctx.Err() != nil {
+		// Server shutdown: kembalikan ke antrian, jangan dianggap gagal
+		p.finish(job, map[string]interface{}{"status": models.ForecastJobQueued, "started_at": nil})
+		return
+	}
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":      models.ForecastJobDone,
+		"run_id":      runID,
+		"error":       "",
+		"finished_at": now,
+	}
+	if err != nil {
+		updates["status"] = models.ForecastJobFailed
+		updates["error"] = err.Error()
+	}
+	p.finish(job, updates)
+}
+
+// finish update job hanya kalau belum diambil ulang oleh worker lain (attempts masih sama)
+func (p *Pool) finish(job *models.ForecastJob, updates map[string]interface{}) {
+	err := p.DB.Model(&models.ForecastJob{}).
+		Where("id = ? AND status = ? AND attempts = ?", job.ID, models.ForecastJobRunning, job.Attempts).
+		Updates(updates).Error
+	if err != nil {
+		log.Printf("Warning: failed to update forecast job %d: %v", job.ID, err)
+	}
+}
+
+func (p *Pool) reap(ctx context.Context) {
+	ticker := time.NewTicker(p.Timeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.requeueStale()
+		}
+	}
+}
+
+// requeueStale job running yang lewat timeout (worker/server mati) dikembalikan ke antrian,
+// atau ditandai failed kalau sudah dicoba MaxAttempts kali
+func (p *Pool) requeueStale() {
+	cutoff := time.Now().Add(-p.Timeout)
+	stale := p.DB.Model(&models.ForecastJob{}).
+		Where("status = ? AND started_at < ?", models.ForecastJobRunning, cutoff).
+		Session(&gorm.Session{})
+
+	failed := stale.Where("attempts >= ?", p.MaxAttempts).Updates(map[string]interface{}{
+		"status":      models.ForecastJobFailed,
+		"error":       fmt.Sprintf("Job did not finish after %d attempt(s)", p.MaxAttempts),
+		"finished_at": time.Now(),
+	})
+	if failed.Error != nil {
+		log.Printf("Warning: failed to expire stale forecast jobs: %v", failed.Error)
+	}
+	requeued := stale.Where("attempts < ?", p.MaxAttempts).Updates(map[string]interface{}{
+		"status":     models.ForecastJobQueued,
+		"started_at": nil,
+	})
+	if requeued.Error != nil {
+		log.Printf("Warning: failed to requeue stale forecast jobs: %v", requeued.Error)
+		return
+	}
+	if requeued.RowsAffected > 0 {
+		log.Printf("Requeued %d stale forecast job(s)", requeued.RowsAffected)
+		p.notify()
+	}
+}
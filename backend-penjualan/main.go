@@ -1,11 +1,17 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
-	"strings"
+	"os/signal"
+	"strconv"
+	"syscall"
 	"time"
+	_ "time/tzdata" // Embed timezone Asia/Jakarta (buat server tanpa tzdata)
 
 	_ "backend-penjualan/docs" // Untuk Swagger
 
@@ -13,10 +19,19 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
-	"backend-penjualan/models"
+	"backend-penjualan/auth"
+	"backend-penjualan/controllers"
+	"backend-penjualan/migrations"
 	"backend-penjualan/routes"
 )
 
+// @title Backend Penjualan API
+// @description API penjualan, stok & forecast. Semua endpoint (kecuali /api/v1/auth/login, /refresh & /logout) wajib login atau API key.
+// @security BearerAuth
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT access token dari POST /api/v1/auth/login ("Bearer <access_token>") atau API key ("Bearer pnj_...")
 func main() {
 	// Load .env
 	if err := godotenv.Load(); err != nil {
@@ -42,23 +57,36 @@ func main() {
 	sqlDB.SetMaxOpenConns(100)
 	sqlDB.SetConnMaxLifetime(time.Hour)
 
-	// Drop existing tables untuk fresh start (hilangin data lama)
-	log.Println("Dropping existing tables for fresh migration...")
-	if err := db.Migrator().DropTable(&models.Product{}); err != nil && !strings.Contains(err.Error(), "does not exist") {
-		log.Printf("Warning: Failed to drop products table: %v", err)
-	}
-	if err := db.Migrator().DropTable(&models.Transaction{}); err != nil && !strings.Contains(err.Error(), "does not exist") {
-		log.Printf("Warning: Failed to drop transactions table: %v", err)
+	// CLI: go run main.go migrate up|down [n]|status
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrateCommand(db, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
 	}
 
-	// Auto-migrate models (sekarang fresh, no conflict)
-	if err := db.AutoMigrate(&models.Product{}, &models.Transaction{}); err != nil {
+	// Versioned migrations (aman, gak pernah drop data kecuali DB_RESET di development)
+	if err := setupSchema(db); err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
-	log.Println("Database migrated successfully (fresh tables created)")
+
+	// Auth: JWT config & admin pertama (kalau tabel users masih kosong)
+	authCfg, err := auth.ConfigFromEnv()
+	if err != nil {
+		log.Fatal("Invalid auth config:", err)
+	}
+	if err := auth.BootstrapAdmin(db); err != nil {
+		log.Fatal("Failed to create admin user:", err)
+	}
+
+	// Worker forecast async, evaluasi akurasi & purge cache jalan sampai server dimatikan (SIGINT/SIGTERM)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	forecastCtrl := controllers.NewForecastController(db)
+	forecastCtrl.StartBackground(ctx)
 
 	// Setup router & run server
-	router := routes.SetupRouter(db)
+	router := routes.SetupRouter(db, authCfg, forecastCtrl)
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
@@ -84,7 +112,93 @@ func main() {
 	*/
 
 	log.Printf("Server starting on port %s", port)
-	if err := router.Run(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+	srv := &http.Server{Addr: ":" + port, Handler: router}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("Failed to start server:", err)
+		}
+	}()
+
+	// Graceful shutdown: job forecast yang lagi jalan dikembalikan ke antrian oleh worker pool
+	<-ctx.Done()
+	stop()
+	log.Println("Shutting down server...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Warning: server shutdown: %v", err)
+	}
+	// Tunggu worker selesai requeue, kalau gak job tetap "running" sampai FORECAST_JOB_TIMEOUT lewat
+	waitCtx, cancelWait := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelWait()
+	if err := forecastCtrl.Wait(waitCtx); err != nil {
+		log.Printf("Warning: forecast workers did not stop in time: %v", err)
+	}
+}
+
+// setupSchema apply migration yang pending saat server start.
+// DB_RESET=true (hanya kalau APP_ENV=development) rollback semua migration dulu -> fresh tables, data hilang.
+func setupSchema(db *gorm.DB) error {
+	if os.Getenv("DB_RESET") == "true" {
+		if os.Getenv("APP_ENV") != "development" {
+			return fmt.Errorf("DB_RESET=true only allowed when APP_ENV=development")
+		}
+		log.Println("WARNING: DB_RESET=true, dropping all tables and re-running migrations...")
+		if err := migrations.Reset(db); err != nil {
+			return err
+		}
+		log.Println("Database reset successfully (fresh tables created)")
+		return nil
+	}
+
+	applied, err := migrations.Up(db)
+	if err != nil {
+		return err
+	}
+	log.Printf("Database migrated successfully (%d new migration(s) applied)", applied)
+	return nil
+}
+
+// runMigrateCommand handle subcommand `migrate up`, `migrate down [n]`, `migrate status`
+func runMigrateCommand(db *gorm.DB, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: migrate up|down [n]|status")
+	}
+	switch args[0] {
+	case "up":
+		applied, err := migrations.Up(db)
+		if err != nil {
+			return err
+		}
+		log.Printf("Applied %d migration(s)", applied)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid step count %q (must be positive number)", args[1])
+			}
+			steps = n
+		}
+		rolledBack, err := migrations.Down(db, steps)
+		if err != nil {
+			return err
+		}
+		log.Printf("Rolled back %d migration(s)", rolledBack)
+	case "status":
+		statuses, err := migrations.Status(db)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied " + s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d  %-40s %s\n", s.Version, s.Name, state)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %q (use up, down or status)", args[0])
 	}
-}
\ No newline at end of file
+	return nil
+}
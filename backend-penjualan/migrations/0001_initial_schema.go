@@ -0,0 +1,38 @@
+package migrations
+
+// Schema awal (products & transactions), sama dengan hasil AutoMigrate sebelumnya.
+// Pakai IF NOT EXISTS supaya database lama yang dibuat AutoMigrate bisa langsung diadopsi.
+func init() {
+	register(Migration{
+		Version: 1,
+		Name:    "initial_schema",
+		Up: exec(
+			`CREATE TABLE IF NOT EXISTS products (
+				id bigserial PRIMARY KEY,
+				nama varchar(100) NOT NULL,
+				harga numeric(15,2) NOT NULL,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_products_deleted_at ON products (deleted_at)`,
+			`CREATE TABLE IF NOT EXISTS transactions (
+				id bigserial PRIMARY KEY,
+				nama_pembeli varchar(100) NOT NULL,
+				product_id bigint NOT NULL,
+				quantity bigint NOT NULL,
+				harga numeric(15,2) NOT NULL,
+				total numeric(15,2) NOT NULL,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz,
+				CONSTRAINT fk_transactions_product FOREIGN KEY (product_id) REFERENCES products (id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_transactions_deleted_at ON transactions (deleted_at)`,
+		),
+		Down: exec(
+			`DROP TABLE IF EXISTS transactions`,
+			`DROP TABLE IF EXISTS products`,
+		),
+	})
+}
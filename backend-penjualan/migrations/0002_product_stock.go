@@ -0,0 +1,17 @@
+package migrations
+
+// Stok produk (inventory), dijaga gak pernah minus lewat CHECK constraint
+func init() {
+	register(Migration{
+		Version: 2,
+		Name:    "product_stock",
+		Up: exec(
+			`ALTER TABLE products ADD COLUMN IF NOT EXISTS stok integer NOT NULL DEFAULT 0`,
+			`ALTER TABLE products ADD CONSTRAINT chk_products_stok CHECK (stok >= 0)`,
+		),
+		Down: exec(
+			`ALTER TABLE products DROP CONSTRAINT IF EXISTS chk_products_stok`,
+			`ALTER TABLE products DROP COLUMN IF EXISTS stok`,
+		),
+	})
+}
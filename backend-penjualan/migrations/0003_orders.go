@@ -0,0 +1,38 @@
+package migrations
+
+// Order multi-item: header (orders) + baris produk (order_items)
+func init() {
+	register(Migration{
+		Version: 3,
+		Name:    "orders",
+		Up: exec(
+			`CREATE TABLE IF NOT EXISTS orders (
+				id bigserial PRIMARY KEY,
+				nama_pembeli varchar(100) NOT NULL,
+				total_quantity bigint NOT NULL,
+				total numeric(15,2) NOT NULL,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_orders_deleted_at ON orders (deleted_at)`,
+			`CREATE TABLE IF NOT EXISTS order_items (
+				id bigserial PRIMARY KEY,
+				order_id bigint NOT NULL,
+				product_id bigint NOT NULL,
+				quantity bigint NOT NULL,
+				harga numeric(15,2) NOT NULL,
+				subtotal numeric(15,2) NOT NULL,
+				created_at timestamptz,
+				updated_at timestamptz,
+				CONSTRAINT fk_orders_items FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE,
+				CONSTRAINT fk_order_items_product FOREIGN KEY (product_id) REFERENCES products (id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items (order_id)`,
+		),
+		Down: exec(
+			`DROP TABLE IF EXISTS order_items`,
+			`DROP TABLE IF EXISTS orders`,
+		),
+	})
+}
@@ -0,0 +1,33 @@
+package migrations
+
+// Customer (pengganti nama_pembeli free-text), transactions & orders di-link lewat customer_id
+func init() {
+	register(Migration{
+		Version: 4,
+		Name:    "customers",
+		Up: exec(
+			`CREATE TABLE IF NOT EXISTS customers (
+				id bigserial PRIMARY KEY,
+				nama varchar(100) NOT NULL,
+				telepon varchar(30),
+				email varchar(100),
+				alamat text,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_customers_deleted_at ON customers (deleted_at)`,
+			`ALTER TABLE transactions ADD COLUMN IF NOT EXISTS customer_id bigint
+				CONSTRAINT fk_transactions_customer REFERENCES customers (id) ON DELETE SET NULL`,
+			`CREATE INDEX IF NOT EXISTS idx_transactions_customer_id ON transactions (customer_id)`,
+			`ALTER TABLE orders ADD COLUMN IF NOT EXISTS customer_id bigint
+				CONSTRAINT fk_orders_customer REFERENCES customers (id) ON DELETE SET NULL`,
+			`CREATE INDEX IF NOT EXISTS idx_orders_customer_id ON orders (customer_id)`,
+		),
+		Down: exec(
+			`ALTER TABLE orders DROP COLUMN IF EXISTS customer_id`,
+			`ALTER TABLE transactions DROP COLUMN IF EXISTS customer_id`,
+			`DROP TABLE IF EXISTS customers`,
+		),
+	})
+}
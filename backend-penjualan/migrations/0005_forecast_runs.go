@@ -0,0 +1,45 @@
+package migrations
+
+// Simpan setiap forecast run & titik hasilnya biar bisa dibandingkan antar minggu
+func init() {
+	register(Migration{
+		Version: 5,
+		Name:    "forecast_runs",
+		Up: exec(
+			`CREATE TABLE IF NOT EXISTS forecast_runs (
+				id bigserial PRIMARY KEY,
+				source varchar(20) NOT NULL,
+				input_hash varchar(64) NOT NULL,
+				input_filename varchar(255),
+				product_id bigint,
+				periods bigint NOT NULL,
+				model_requested varchar(30),
+				model varchar(30),
+				fallback_reason text,
+				status varchar(20) NOT NULL,
+				error text,
+				created_at timestamptz
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_forecast_runs_input_hash ON forecast_runs (input_hash)`,
+			`CREATE INDEX IF NOT EXISTS idx_forecast_runs_product_id ON forecast_runs (product_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_forecast_runs_status ON forecast_runs (status)`,
+			`CREATE INDEX IF NOT EXISTS idx_forecast_runs_created_at ON forecast_runs (created_at)`,
+			`CREATE TABLE IF NOT EXISTS forecast_points (
+				id bigserial PRIMARY KEY,
+				run_id bigint NOT NULL,
+				kind varchar(20) NOT NULL,
+				date date NOT NULL,
+				actual numeric,
+				yhat numeric,
+				yhat_lower numeric,
+				yhat_upper numeric,
+				CONSTRAINT fk_forecast_runs_points FOREIGN KEY (run_id) REFERENCES forecast_runs (id) ON DELETE CASCADE
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_forecast_points_run_id ON forecast_points (run_id)`,
+		),
+		Down: exec(
+			`DROP TABLE IF EXISTS forecast_points`,
+			`DROP TABLE IF EXISTS forecast_runs`,
+		),
+	})
+}
@@ -0,0 +1,34 @@
+package migrations
+
+// Antrian forecast async (POST /forecast/jobs), diproses worker pool di background
+func init() {
+	register(Migration{
+		Version: 6,
+		Name:    "forecast_jobs",
+		Up: exec(
+			`CREATE TABLE IF NOT EXISTS forecast_jobs (
+				id bigserial PRIMARY KEY,
+				status varchar(20) NOT NULL,
+				source varchar(20) NOT NULL,
+				input_hash varchar(64) NOT NULL,
+				input_filename varchar(255),
+				product_id bigint,
+				model varchar(30),
+				periods bigint NOT NULL,
+				input text NOT NULL,
+				run_id bigint,
+				error text,
+				attempts bigint NOT NULL DEFAULT 0,
+				started_at timestamptz,
+				finished_at timestamptz,
+				created_at timestamptz,
+				updated_at timestamptz,
+				CONSTRAINT fk_forecast_jobs_run FOREIGN KEY (run_id) REFERENCES forecast_runs (id) ON DELETE SET NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_forecast_jobs_status ON forecast_jobs (status)`,
+		),
+		Down: exec(
+			`DROP TABLE IF EXISTS forecast_jobs`,
+		),
+	})
+}
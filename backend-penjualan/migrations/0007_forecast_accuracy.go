@@ -0,0 +1,23 @@
+package migrations
+
+// Kolom akurasi forecast run (dibandingkan dengan penjualan aktual yang masuk belakangan)
+func init() {
+	register(Migration{
+		Version: 7,
+		Name:    "forecast_accuracy",
+		Up: exec(
+			`ALTER TABLE forecast_runs ADD COLUMN IF NOT EXISTS mape numeric`,
+			`ALTER TABLE forecast_runs ADD COLUMN IF NOT EXISTS mae numeric`,
+			`ALTER TABLE forecast_runs ADD COLUMN IF NOT EXISTS rmse numeric`,
+			`ALTER TABLE forecast_runs ADD COLUMN IF NOT EXISTS evaluated_points bigint NOT NULL DEFAULT 0`,
+			`ALTER TABLE forecast_runs ADD COLUMN IF NOT EXISTS evaluated_at timestamptz`,
+		),
+		Down: exec(
+			`ALTER TABLE forecast_runs DROP COLUMN IF EXISTS evaluated_at`,
+			`ALTER TABLE forecast_runs DROP COLUMN IF EXISTS evaluated_points`,
+			`ALTER TABLE forecast_runs DROP COLUMN IF EXISTS rmse`,
+			`ALTER TABLE forecast_runs DROP COLUMN IF EXISTS mae`,
+			`ALTER TABLE forecast_runs DROP COLUMN IF EXISTS mape`,
+		),
+	})
+}
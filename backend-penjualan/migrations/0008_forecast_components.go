@@ -0,0 +1,19 @@
+package migrations
+
+// Komponen forecast (trend, musiman mingguan/tahunan) per titik
+func init() {
+	register(Migration{
+		Version: 8,
+		Name:    "forecast_components",
+		Up: exec(
+			`ALTER TABLE forecast_points ADD COLUMN IF NOT EXISTS trend numeric`,
+			`ALTER TABLE forecast_points ADD COLUMN IF NOT EXISTS weekly numeric`,
+			`ALTER TABLE forecast_points ADD COLUMN IF NOT EXISTS yearly numeric`,
+		),
+		Down: exec(
+			`ALTER TABLE forecast_points DROP COLUMN IF EXISTS yearly`,
+			`ALTER TABLE forecast_points DROP COLUMN IF EXISTS weekly`,
+			`ALTER TABLE forecast_points DROP COLUMN IF EXISTS trend`,
+		),
+	})
+}
@@ -0,0 +1,21 @@
+package migrations
+
+// Cache hasil forecast (opsional, FORECAST_CACHE_PERSIST=true)
+func init() {
+	register(Migration{
+		Version: 9,
+		Name:    "forecast_cache",
+		Up: exec(
+			`CREATE TABLE IF NOT EXISTS forecast_cache (
+				key varchar(64) PRIMARY KEY,
+				value bytea NOT NULL,
+				expires_at timestamptz NOT NULL,
+				created_at timestamptz
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_forecast_cache_expires_at ON forecast_cache (expires_at)`,
+		),
+		Down: exec(
+			`DROP TABLE IF EXISTS forecast_cache`,
+		),
+	})
+}
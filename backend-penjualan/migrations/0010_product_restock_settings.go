@@ -0,0 +1,19 @@
+package migrations
+
+// Setting restock per produk (lead time supplier & safety stock) buat rekomendasi reorder
+func init() {
+	register(Migration{
+		Version: 10,
+		Name:    "product_restock_settings",
+		Up: exec(
+			`ALTER TABLE products ADD COLUMN IF NOT EXISTS lead_time_days integer NOT NULL DEFAULT 7`,
+			`ALTER TABLE products ADD COLUMN IF NOT EXISTS safety_stock integer NOT NULL DEFAULT 0`,
+			`ALTER TABLE products ADD CONSTRAINT chk_products_restock CHECK (lead_time_days >= 0 AND safety_stock >= 0)`,
+		),
+		Down: exec(
+			`ALTER TABLE products DROP CONSTRAINT IF EXISTS chk_products_restock`,
+			`ALTER TABLE products DROP COLUMN IF EXISTS safety_stock`,
+			`ALTER TABLE products DROP COLUMN IF EXISTS lead_time_days`,
+		),
+	})
+}
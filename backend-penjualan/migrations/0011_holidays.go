@@ -0,0 +1,70 @@
+package migrations
+
+// Kalender libur nasional & event belanja (regressor forecast). Seed 2025-2026;
+// tanggal yang berpindah (Lebaran, Imlek, Waisak, ...) ditambah per tahun lewat /holidays.
+func init() {
+	register(Migration{
+		Version: 11,
+		Name:    "holidays",
+		Up: exec(
+			`CREATE TABLE IF NOT EXISTS holidays (
+				id bigserial PRIMARY KEY,
+				nama varchar(100) NOT NULL,
+				tanggal date NOT NULL,
+				kategori varchar(20) NOT NULL DEFAULT 'nasional',
+				lower_window integer NOT NULL DEFAULT 0,
+				upper_window integer NOT NULL DEFAULT 0,
+				created_at timestamptz,
+				updated_at timestamptz,
+				CONSTRAINT uq_holidays_nama_tanggal UNIQUE (nama, tanggal),
+				CONSTRAINT chk_holidays_window CHECK (lower_window <= 0 AND upper_window >= 0)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_holidays_tanggal ON holidays (tanggal)`,
+			`INSERT INTO holidays (nama, tanggal, kategori, lower_window, upper_window, created_at, updated_at) VALUES
+				('Tahun Baru Masehi', '2025-01-01', 'nasional', 0, 0, now(), now()),
+				('Isra Mikraj', '2025-01-27', 'nasional', 0, 0, now(), now()),
+				('Tahun Baru Imlek', '2025-01-29', 'nasional', -3, 0, now(), now()),
+				('Hari Raya Nyepi', '2025-03-29', 'nasional', 0, 0, now(), now()),
+				('Hari Raya Idul Fitri', '2025-03-31', 'nasional', -7, 2, now(), now()),
+				('Hari Raya Idul Fitri', '2025-04-01', 'nasional', 0, 2, now(), now()),
+				('Wafat Yesus Kristus', '2025-04-18', 'nasional', 0, 0, now(), now()),
+				('Hari Paskah', '2025-04-20', 'nasional', 0, 0, now(), now()),
+				('Hari Buruh', '2025-05-01', 'nasional', 0, 0, now(), now()),
+				('Hari Raya Waisak', '2025-05-12', 'nasional', 0, 0, now(), now()),
+				('Kenaikan Yesus Kristus', '2025-05-29', 'nasional', 0, 0, now(), now()),
+				('Hari Lahir Pancasila', '2025-06-01', 'nasional', 0, 0, now(), now()),
+				('Hari Raya Idul Adha', '2025-06-06', 'nasional', -2, 0, now(), now()),
+				('Tahun Baru Islam', '2025-06-27', 'nasional', 0, 0, now(), now()),
+				('Hari Kemerdekaan RI', '2025-08-17', 'nasional', 0, 0, now(), now()),
+				('Maulid Nabi Muhammad', '2025-09-05', 'nasional', 0, 0, now(), now()),
+				('Hari Raya Natal', '2025-12-25', 'nasional', -3, 0, now(), now()),
+				('Tahun Baru Masehi', '2026-01-01', 'nasional', 0, 0, now(), now()),
+				('Isra Mikraj', '2026-01-16', 'nasional', 0, 0, now(), now()),
+				('Tahun Baru Imlek', '2026-02-17', 'nasional', -3, 0, now(), now()),
+				('Hari Raya Nyepi', '2026-03-19', 'nasional', 0, 0, now(), now()),
+				('Hari Raya Idul Fitri', '2026-03-20', 'nasional', -7, 2, now(), now()),
+				('Hari Raya Idul Fitri', '2026-03-21', 'nasional', 0, 2, now(), now()),
+				('Wafat Yesus Kristus', '2026-04-03', 'nasional', 0, 0, now(), now()),
+				('Hari Paskah', '2026-04-05', 'nasional', 0, 0, now(), now()),
+				('Hari Buruh', '2026-05-01', 'nasional', 0, 0, now(), now()),
+				('Kenaikan Yesus Kristus', '2026-05-14', 'nasional', 0, 0, now(), now()),
+				('Hari Raya Idul Adha', '2026-05-27', 'nasional', -2, 0, now(), now()),
+				('Hari Raya Waisak', '2026-05-31', 'nasional', 0, 0, now(), now()),
+				('Hari Lahir Pancasila', '2026-06-01', 'nasional', 0, 0, now(), now()),
+				('Tahun Baru Islam', '2026-06-16', 'nasional', 0, 0, now(), now()),
+				('Hari Kemerdekaan RI', '2026-08-17', 'nasional', 0, 0, now(), now()),
+				('Maulid Nabi Muhammad', '2026-08-25', 'nasional', 0, 0, now(), now()),
+				('Hari Raya Natal', '2026-12-25', 'nasional', -3, 0, now(), now()),
+				('Harbolnas 11.11', '2025-11-11', 'event', 0, 0, now(), now()),
+				('Harbolnas 12.12', '2025-12-12', 'event', 0, 0, now(), now()),
+				('Harbolnas 11.11', '2026-11-11', 'event', 0, 0, now(), now()),
+				('Harbolnas 12.12', '2026-12-12', 'event', 0, 0, now(), now())
+			ON CONFLICT (nama, tanggal) DO NOTHING`,
+			`ALTER TABLE forecast_points ADD COLUMN IF NOT EXISTS holidays numeric`,
+		),
+		Down: exec(
+			`ALTER TABLE forecast_points DROP COLUMN IF EXISTS holidays`,
+			`DROP TABLE IF EXISTS holidays`,
+		),
+	})
+}
@@ -0,0 +1,39 @@
+package migrations
+
+// User login + refresh token (sesi JWT). Admin pertama dibuat saat start dari ADMIN_USERNAME/ADMIN_PASSWORD.
+func init() {
+	register(Migration{
+		Version: 12,
+		Name:    "users",
+		Up: exec(
+			`CREATE TABLE IF NOT EXISTS users (
+				id bigserial PRIMARY KEY,
+				username varchar(50) NOT NULL,
+				nama varchar(100),
+				password_hash varchar(100) NOT NULL,
+				role varchar(20) NOT NULL DEFAULT 'cashier',
+				active boolean NOT NULL DEFAULT true,
+				last_login_at timestamptz,
+				created_at timestamptz,
+				updated_at timestamptz,
+				CONSTRAINT chk_users_role CHECK (role IN ('admin', 'manager', 'cashier'))
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username ON users (lower(username))`,
+			`CREATE TABLE IF NOT EXISTS refresh_tokens (
+				id bigserial PRIMARY KEY,
+				user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				token_hash varchar(64) NOT NULL,
+				expires_at timestamptz NOT NULL,
+				revoked_at timestamptz,
+				user_agent varchar(255),
+				created_at timestamptz
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_tokens_token_hash ON refresh_tokens (token_hash)`,
+			`CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens (user_id)`,
+		),
+		Down: exec(
+			`DROP TABLE IF EXISTS refresh_tokens`,
+			`DROP TABLE IF EXISTS users`,
+		),
+	})
+}
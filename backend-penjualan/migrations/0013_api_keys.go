@@ -0,0 +1,28 @@
+package migrations
+
+// API key (hash SHA-256 + scopes) buat integrasi ERP / ML service tanpa login user
+func init() {
+	register(Migration{
+		Version: 13,
+		Name:    "api_keys",
+		Up: exec(
+			`CREATE TABLE IF NOT EXISTS api_keys (
+				id bigserial PRIMARY KEY,
+				name varchar(100) NOT NULL,
+				prefix varchar(20) NOT NULL,
+				key_hash varchar(64) NOT NULL,
+				scopes jsonb NOT NULL DEFAULT '[]',
+				created_by_id bigint REFERENCES users(id) ON DELETE SET NULL,
+				expires_at timestamptz,
+				last_used_at timestamptz,
+				last_used_ip varchar(45),
+				revoked_at timestamptz,
+				created_at timestamptz
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_key_hash ON api_keys (key_hash)`,
+		),
+		Down: exec(
+			`DROP TABLE IF EXISTS api_keys`,
+		),
+	})
+}
@@ -0,0 +1,48 @@
+package migrations
+
+// Multi-store: produk (dengan stoknya), transaksi & order milik satu toko. Data lama masuk ke toko bawaan (id 1).
+// User & API key dengan store_id null = kantor pusat (akses semua toko).
+func init() {
+	register(Migration{
+		Version: 14,
+		Name:    "stores",
+		Up: exec(
+			`CREATE TABLE IF NOT EXISTS stores (
+				id bigserial PRIMARY KEY,
+				kode varchar(20) NOT NULL,
+				nama varchar(100) NOT NULL,
+				alamat text,
+				active boolean NOT NULL DEFAULT true,
+				created_at timestamptz,
+				updated_at timestamptz
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_stores_kode ON stores (lower(kode))`,
+			`INSERT INTO stores (id, kode, nama, active, created_at, updated_at)
+			VALUES (1, 'UTAMA', 'Toko Utama', true, now(), now())
+			ON CONFLICT (id) DO NOTHING`,
+			`SELECT setval(pg_get_serial_sequence('stores', 'id'), (SELECT MAX(id) FROM stores))`,
+
+			// DEFAULT 1 cuma buat backfill data lama, setelah itu store_id wajib diisi aplikasi
+			`ALTER TABLE products ADD COLUMN IF NOT EXISTS store_id bigint NOT NULL DEFAULT 1 REFERENCES stores(id)`,
+			`ALTER TABLE products ALTER COLUMN store_id DROP DEFAULT`,
+			`CREATE INDEX IF NOT EXISTS idx_products_store_id ON products (store_id)`,
+			`ALTER TABLE transactions ADD COLUMN IF NOT EXISTS store_id bigint NOT NULL DEFAULT 1 REFERENCES stores(id)`,
+			`ALTER TABLE transactions ALTER COLUMN store_id DROP DEFAULT`,
+			`CREATE INDEX IF NOT EXISTS idx_transactions_store_id_created_at ON transactions (store_id, created_at)`,
+			`ALTER TABLE orders ADD COLUMN IF NOT EXISTS store_id bigint NOT NULL DEFAULT 1 REFERENCES stores(id)`,
+			`ALTER TABLE orders ALTER COLUMN store_id DROP DEFAULT`,
+			`CREATE INDEX IF NOT EXISTS idx_orders_store_id ON orders (store_id)`,
+
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS store_id bigint REFERENCES stores(id)`,
+			`ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS store_id bigint REFERENCES stores(id)`,
+		),
+		Down: exec(
+			`ALTER TABLE api_keys DROP COLUMN IF EXISTS store_id`,
+			`ALTER TABLE users DROP COLUMN IF EXISTS store_id`,
+			`ALTER TABLE orders DROP COLUMN IF EXISTS store_id`,
+			`ALTER TABLE transactions DROP COLUMN IF EXISTS store_id`,
+			`ALTER TABLE products DROP COLUMN IF EXISTS store_id`,
+			`DROP TABLE IF EXISTS stores`,
+		),
+	})
+}
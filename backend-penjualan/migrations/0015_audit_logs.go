@@ -0,0 +1,32 @@
+package migrations
+
+// Audit log perubahan produk & transaksi (actor, diff before/after, request ID)
+func init() {
+	register(Migration{
+		Version: 15,
+		Name:    "audit_logs",
+		Up: exec(
+			`CREATE TABLE IF NOT EXISTS audit_logs (
+				id bigserial PRIMARY KEY,
+				actor_type varchar(20) NOT NULL,
+				actor_id bigint,
+				actor_name varchar(100) NOT NULL,
+				action varchar(20) NOT NULL,
+				entity varchar(50) NOT NULL,
+				entity_id bigint NOT NULL,
+				store_id bigint,
+				changes jsonb NOT NULL DEFAULT '{}',
+				request_id varchar(100),
+				ip varchar(45),
+				created_at timestamptz NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs (entity, entity_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at)`,
+			`CREATE INDEX IF NOT EXISTS idx_audit_logs_store_id ON audit_logs (store_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_audit_logs_request_id ON audit_logs (request_id)`,
+		),
+		Down: exec(
+			`DROP TABLE IF EXISTS audit_logs`,
+		),
+	})
+}
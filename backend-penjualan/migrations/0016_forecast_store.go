@@ -0,0 +1,21 @@
+package migrations
+
+// Forecast run & job ikut toko yang membuatnya (null = kantor pusat). Run/job lama per produk diisi dari toko produknya.
+func init() {
+	register(Migration{
+		Version: 16,
+		Name:    "forecast_store",
+		Up: exec(
+			`ALTER TABLE forecast_runs ADD COLUMN IF NOT EXISTS store_id bigint REFERENCES stores(id)`,
+			`UPDATE forecast_runs r SET store_id = p.store_id FROM products p WHERE r.product_id = p.id AND r.store_id IS NULL`,
+			`CREATE INDEX IF NOT EXISTS idx_forecast_runs_store_id ON forecast_runs (store_id)`,
+			`ALTER TABLE forecast_jobs ADD COLUMN IF NOT EXISTS store_id bigint REFERENCES stores(id)`,
+			`UPDATE forecast_jobs j SET store_id = p.store_id FROM products p WHERE j.product_id = p.id AND j.store_id IS NULL`,
+			`CREATE INDEX IF NOT EXISTS idx_forecast_jobs_store_id ON forecast_jobs (store_id)`,
+		),
+		Down: exec(
+			`ALTER TABLE forecast_jobs DROP COLUMN IF EXISTS store_id`,
+			`ALTER TABLE forecast_runs DROP COLUMN IF EXISTS store_id`,
+		),
+	})
+}
@@ -0,0 +1,17 @@
+package migrations
+
+// Order gak pernah dihapus (gak ada endpoint delete), kolom deleted_at bawaan 0003 gak pernah dipakai
+func init() {
+	register(Migration{
+		Version: 17,
+		Name:    "orders_drop_deleted_at",
+		Up: exec(
+			`DROP INDEX IF EXISTS idx_orders_deleted_at`,
+			`ALTER TABLE orders DROP COLUMN IF EXISTS deleted_at`,
+		),
+		Down: exec(
+			`ALTER TABLE orders ADD COLUMN IF NOT EXISTS deleted_at timestamptz`,
+			`CREATE INDEX IF NOT EXISTS idx_orders_deleted_at ON orders (deleted_at)`,
+		),
+	})
+}
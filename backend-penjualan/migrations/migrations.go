@@ -0,0 +1,167 @@
+package migrations
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration satu langkah perubahan schema yang bernomor (Version) dan bisa di-rollback (Down)
+type Migration struct {
+	Version uint
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// SchemaMigration row di tabel schema_migrations (migration yang sudah di-apply)
+type SchemaMigration struct {
+	Version   uint      `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"size:255;not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// MigrationStatus status satu migration (applied atau pending)
+type MigrationStatus struct {
+	Version   uint
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+var registry []Migration
+
+// register dipanggil dari init() tiap file migration
+func register(m Migration) {
+	registry = append(registry, m)
+}
+
+// All return semua migration terurut berdasarkan Version
+func All() []Migration {
+	sorted := make([]Migration, len(registry))
+	copy(sorted, registry)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	return sorted
+}
+
+// exec helper buat migration yang isinya cuma SQL statement
+func exec(statements ...string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		for _, stmt := range statements {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func ensureTable(db *gorm.DB) error {
+	return db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version bigint PRIMARY KEY,
+		name varchar(255) NOT NULL,
+		applied_at timestamptz NOT NULL
+	)`).Error
+}
+
+func applied(db *gorm.DB) (map[uint]SchemaMigration, error) {
+	if err := ensureTable(db); err != nil {
+		return nil, fmt.Errorf("create schema_migrations: %w", err)
+	}
+	var rows []SchemaMigration
+	if err := db.Order("version").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[uint]SchemaMigration, len(rows))
+	for _, row := range rows {
+		result[row.Version] = row
+	}
+	return result, nil
+}
+
+// Up apply semua migration yang masih pending, masing-masing dalam satu DB transaction.
+// Return jumlah migration yang di-apply.
+func Up(db *gorm.DB) (int, error) {
+	done, err := applied(db)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, m := range All() {
+		if _, ok := done[m.Version]; ok {
+			continue
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return count, fmt.Errorf("migration %04d_%s up: %w", m.Version, m.Name, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Down rollback `steps` migration terakhir yang sudah di-apply (urut dari versi terbesar)
+func Down(db *gorm.DB, steps int) (int, error) {
+	done, err := applied(db)
+	if err != nil {
+		return 0, err
+	}
+	all := All()
+	count := 0
+	for i := len(all) - 1; i >= 0 && count < steps; i-- {
+		m := all[i]
+		if _, ok := done[m.Version]; !ok {
+			continue
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&SchemaMigration{}, m.Version).Error
+		})
+		if err != nil {
+			return count, fmt.Errorf("migration %04d_%s down: %w", m.Version, m.Name, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Status return daftar semua migration beserta status applied/pending
+func Status(db *gorm.DB) ([]MigrationStatus, error) {
+	done, err := applied(db)
+	if err != nil {
+		return nil, err
+	}
+	var result []MigrationStatus
+	for _, m := range All() {
+		status := MigrationStatus{Version: m.Version, Name: m.Name}
+		if row, ok := done[m.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+		result = append(result, status)
+	}
+	return result, nil
+}
+
+// Reset rollback semua migration lalu apply ulang dari awal. HAPUS SEMUA DATA, khusus development.
+func Reset(db *gorm.DB) error {
+	if _, err := Down(db, len(registry)); err != nil {
+		return err
+	}
+	_, err := Up(db)
+	return err
+}
@@ -0,0 +1,88 @@
+package mlclient
+
+import (
+	"sync"
+	"time"
+)
+
+// State circuit breaker
+const (
+	StateClosed   = "closed"    // Normal, request diteruskan
+	StateOpen     = "open"      // ML dianggap down, request langsung ditolak
+	StateHalfOpen = "half_open" // Cooldown lewat, satu request percobaan diizinkan
+)
+
+// Breaker circuit breaker sederhana: open setelah threshold kegagalan berturut-turut,
+// setelah cooldown izinkan satu request percobaan (half-open) sebelum close lagi.
+type Breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	state     string
+	openedAt  time.Time
+	probing   bool
+}
+
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{threshold: threshold, cooldown: cooldown, state: StateClosed}
+}
+
+// Allow true kalau request boleh dikirim
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case StateOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = StateHalfOpen
+		b.probing = true
+		return true
+	case StateHalfOpen:
+		if b.probing {
+			return false // Masih nunggu hasil request percobaan
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.state = StateClosed
+	b.probing = false
+}
+
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.probing = false
+	if b.state == StateHalfOpen || b.failures >= b.threshold {
+		b.state = StateOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// Release request selesai tanpa hasil yang bisa dinilai (mis. dibatalkan client)
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// State state saat ini (open yang sudah lewat cooldown dilaporkan half_open)
+func (b *Breaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateOpen && time.Since(b.openedAt) >= b.cooldown {
+		return StateHalfOpen
+	}
+	return b.state
+}
@@ -0,0 +1,51 @@
+package mlclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	// Langkah: allow/deny = cek hasil Allow(), cooldown = anggap cooldown sudah lewat
+	tests := []struct {
+		name      string
+		steps     []string
+		wantState string
+	}{
+		{"closed allows requests", []string{"allow", "allow", "success"}, StateClosed},
+		{"stays closed below threshold", []string{"failure", "failure", "allow"}, StateClosed},
+		{"opens at threshold", []string{"failure", "failure", "failure", "deny", "deny"}, StateOpen},
+		{"success resets failure count", []string{"failure", "failure", "success", "failure", "failure", "allow"}, StateClosed},
+		{"half open after cooldown", []string{"failure", "failure", "failure", "cooldown"}, StateHalfOpen},
+		{"half open allows a single probe", []string{"failure", "failure", "failure", "cooldown", "allow", "deny"}, StateHalfOpen},
+		{"probe success closes", []string{"failure", "failure", "failure", "cooldown", "allow", "success", "allow", "allow"}, StateClosed},
+		{"probe failure reopens", []string{"failure", "failure", "failure", "cooldown", "allow", "failure", "deny"}, StateOpen},
+		{"release frees the probe", []string{"failure", "failure", "failure", "cooldown", "allow", "release", "allow"}, StateHalfOpen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBreaker(3, time.Minute)
+			for i, step := range tt.steps {
+				switch step {
+				case "allow", "deny":
+					if got, want := b.Allow(), step == "allow"; got != want {
+						t.Fatalf("step %d: Allow() = %v, want %v", i, got, want)
+					}
+				case "success":
+					b.Success()
+				case "failure":
+					b.Failure()
+				case "release":
+					b.Release()
+				case "cooldown":
+					b.openedAt = time.Now().Add(-time.Minute)
+				default:
+					t.Fatalf("unknown step %q", step)
+				}
+			}
+			if got := b.State(); got != tt.wantState {
+				t.Errorf("State() = %q, want %q", got, tt.wantState)
+			}
+		})
+	}
+}
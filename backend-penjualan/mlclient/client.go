@@ -0,0 +1,268 @@
+// Package mlclient HTTP client ke ML service (FastAPI/Prophet) dengan timeout yang bisa diatur,
+// retry + backoff untuk error 5xx/jaringan, circuit breaker, dan health check.
+package mlclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client interface ke ML service. Implementasi default HTTPClient; test bisa pakai fake
+// (atau HTTPClient yang diarahkan ke httptest.Server lewat Config.URL / Config.HTTPClient).
+type Client interface {
+	Predict(ctx context.Context, req PredictRequest) ([]byte, error)
+	Health(ctx context.Context) Health
+}
+
+// PredictRequest input ke endpoint predict: CSV date,projected_quantity + jumlah hari
+type PredictRequest struct {
+	CSV     []byte
+	Periods int
+	Fields  map[string]string // Field form tambahan (opsional)
+}
+
+// Error error dari ML service (unreachable, status non-200, circuit open, atau payload gak valid)
+type Error struct {
+	Message string
+	Details string
+	Status  int    // Status code dari ML service (0 kalau unreachable / circuit open)
+	Raw     string // Body mentah kalau gagal parse
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Message, e.Details)
+}
+
+// ErrCircuitOpen request gak dikirim karena ML service lagi dianggap down
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// Config konfigurasi client; nilai kosong diisi default
+type Config struct {
+	URL              string        // Endpoint predict (ML_SERVICE_URL)
+	HealthURL        string        // Endpoint health (ML_HEALTH_URL, default <base>/health)
+	Timeout          time.Duration // Timeout per request (ML_TIMEOUT, default 30s)
+	MaxRetries       int           // Retry kalau 5xx / jaringan error (ML_MAX_RETRIES, default 2)
+	RetryBackoff     time.Duration // Backoff awal, dobel tiap retry (ML_RETRY_BACKOFF, default 500ms)
+	BreakerThreshold int           // Gagal berturut-turut sebelum circuit open (ML_BREAKER_THRESHOLD, default 5)
+	BreakerCooldown  time.Duration // Lama circuit open sebelum dicoba lagi (ML_BREAKER_COOLDOWN, default 30s)
+	HTTPClient       *http.Client  // Optional: transport custom (test, proxy, dll)
+}
+
+const defaultURL = "http://localhost:8000/predict"
+
+// ConfigFromEnv baca konfigurasi dari env (lihat komentar field Config)
+func ConfigFromEnv() Config {
+	cfg := Config{
+		URL:              os.Getenv("ML_SERVICE_URL"),
+		HealthURL:        os.Getenv("ML_HEALTH_URL"),
+		Timeout:          envDuration("ML_TIMEOUT", 30*time.Second),
+		MaxRetries:       envInt("ML_MAX_RETRIES", 2),
+		RetryBackoff:     envDuration("ML_RETRY_BACKOFF", 500*time.Millisecond),
+		BreakerThreshold: envInt("ML_BREAKER_THRESHOLD", 5),
+		BreakerCooldown:  envDuration("ML_BREAKER_COOLDOWN", 30*time.Second),
+	}
+	return cfg
+}
+
+func envDuration(key string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(key)); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
+
+func envInt(key string, fallback int) int {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n >= 0 {
+		return n
+	}
+	return fallback
+}
+
+// HTTPClient implementasi Client via HTTP multipart (FastAPI expect field csv_file & periods)
+type HTTPClient struct {
+	cfg     Config
+	http    *http.Client
+	breaker *Breaker
+}
+
+// New buat HTTPClient dari cfg (field kosong diisi default)
+func New(cfg Config) *HTTPClient {
+	if cfg.URL == "" {
+		cfg.URL = defaultURL
+	}
+	if cfg.HealthURL == "" {
+		cfg.HealthURL = strings.TrimSuffix(strings.TrimSuffix(cfg.URL, "/"), "/predict") + "/health"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = 500 * time.Millisecond
+	}
+	if cfg.BreakerThreshold <= 0 {
+		cfg.BreakerThreshold = 5
+	}
+	if cfg.BreakerCooldown <= 0 {
+		cfg.BreakerCooldown = 30 * time.Second
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	return &HTTPClient{
+		cfg:     cfg,
+		http:    httpClient,
+		breaker: NewBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+	}
+}
+
+// URL endpoint predict yang dipakai
+func (c *HTTPClient) URL() string { return c.cfg.URL }
+
+// Predict kirim series ke ML service & return body JSON mentah (status 200).
+// 5xx & error jaringan di-retry dengan backoff; kalau terus gagal circuit breaker open
+// dan request berikutnya langsung ditolak (Error status 0) sampai cooldown lewat.
+func (c *HTTPClient) Predict(ctx context.Context, req PredictRequest) ([]byte, error) {
+	if !c.breaker.Allow() {
+		return nil, &Error{Message: "ML service unavailable", Details: ErrCircuitOpen.Error()}
+	}
+
+	var lastErr *Error
+	for attempt := 0; attempt <= c.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, backoff(c.cfg.RetryBackoff, attempt)); err != nil {
+				break
+			}
+		}
+		body, err := c.predictOnce(ctx, req)
+		if err == nil {
+			c.breaker.Success()
+			return body, nil
+		}
+		lastErr = err
+		if !retryable(err) || ctx.Err() != nil {
+			break
+		}
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		c.breaker.Release() // Dibatalkan dari sisi kita, bukan salah ML service
+	case retryable(lastErr):
+		c.breaker.Failure()
+	default:
+		c.breaker.Success() // 4xx = ML service hidup, input-nya yang ditolak
+	}
+	return nil, lastErr
+}
+
+func (c *HTTPClient) predictOnce(ctx context.Context, req PredictRequest) ([]byte, *Error) {
+	// Buat multipart form buat FastAPI
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("csv_file", "series.csv") // FastAPI expect "csv_file"
+	if err != nil {
+		return nil, &Error{Message: "Failed to create form", Details: err.Error(), Status: http.StatusBadRequest}
+	}
+	part.Write(req.CSV)
+	writer.WriteField("periods", strconv.Itoa(req.Periods))
+	for key, value := range req.Fields {
+		writer.WriteField(key, value)
+	}
+	writer.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.URL, body)
+	if err != nil {
+		return nil, &Error{Message: "Failed to create request", Details: err.Error(), Status: http.StatusBadRequest}
+	}
+	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return nil, &Error{Message: "ML service unreachable", Details: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &Error{Message: "Failed to read response", Details: err.Error()}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &Error{Message: "Prediction failed", Details: string(bodyBytes), Status: resp.StatusCode}
+	}
+	return bodyBytes, nil
+}
+
+// retryable error jaringan (status 0) & 5xx
+func retryable(err *Error) bool {
+	return err != nil && (err.Status == 0 || err.Status >= http.StatusInternalServerError)
+}
+
+// backoff eksponensial (base * 2^(attempt-1)) + jitter sampai 20%
+func backoff(base time.Duration, attempt int) time.Duration {
+	d := base << (attempt - 1)
+	return d + time.Duration(rand.Int63n(int64(d)/5+1))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Health status ML service
+type Health struct {
+	Status     string `json:"status"` // up / down
+	URL        string `json:"url"`
+	StatusCode int    `json:"status_code,omitempty"`
+	LatencyMs  int64  `json:"latency_ms"`
+	Breaker    string `json:"breaker"` // closed / open / half_open
+	Error      string `json:"error,omitempty"`
+}
+
+// Health GET ke endpoint health ML service (gak mempengaruhi circuit breaker)
+func (c *HTTPClient) Health(ctx context.Context) Health {
+	h := Health{Status: "down", URL: c.cfg.HealthURL, Breaker: c.breaker.State()}
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.HealthURL, nil)
+	if err != nil {
+		h.Error = err.Error()
+		return h
+	}
+	resp, err := c.http.Do(req)
+	h.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		h.Error = err.Error()
+		return h
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	h.StatusCode = resp.StatusCode
+	if resp.StatusCode != http.StatusOK {
+		h.Error = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+		return h
+	}
+	h.Status = "up"
+	return h
+}
@@ -0,0 +1,151 @@
+package mlclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestServer ML service palsu: request ke-n dijawab statuses[n] (status terakhir diulang)
+func newTestServer(t *testing.T, statuses []int, hits *int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(hits, 1)) - 1
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("parse multipart: %v", err)
+		} else {
+			if got := r.FormValue("periods"); got != "7" {
+				t.Errorf("periods = %q, want 7", got)
+			}
+			file, _, err := r.FormFile("csv_file")
+			if err != nil {
+				t.Errorf("csv_file missing: %v", err)
+			} else {
+				data, _ := io.ReadAll(file)
+				if string(data) != "date,projected_quantity\n2025-01-01,3\n" {
+					t.Errorf("csv_file = %q", data)
+				}
+			}
+		}
+		status := statuses[len(statuses)-1]
+		if n < len(statuses) {
+			status = statuses[n]
+		}
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			io.WriteString(w, `{"forecast":[]}`)
+		} else {
+			io.WriteString(w, `{"detail":"boom"}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func testRequest() PredictRequest {
+	return PredictRequest{CSV: []byte("date,projected_quantity\n2025-01-01,3\n"), Periods: 7}
+}
+
+func TestHTTPClientPredict(t *testing.T) {
+	tests := []struct {
+		name        string
+		statuses    []int
+		maxRetries  int
+		wantHits    int32
+		wantStatus  int // 0 = sukses
+		wantBreaker string
+	}{
+		{"success", []int{200}, 2, 1, 0, StateClosed},
+		{"retries 5xx then succeeds", []int{500, 502, 200}, 2, 3, 0, StateClosed},
+		{"gives up after max retries", []int{503}, 2, 3, http.StatusServiceUnavailable, StateOpen},
+		{"no retries configured", []int{500, 200}, 0, 1, http.StatusInternalServerError, StateOpen},
+		{"4xx is not retried", []int{400, 200}, 2, 1, http.StatusBadRequest, StateClosed},
+		{"4xx after 5xx stops retrying", []int{500, 422, 200}, 2, 2, http.StatusUnprocessableEntity, StateClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits int32
+			srv := newTestServer(t, tt.statuses, &hits)
+			client := New(Config{URL: srv.URL, MaxRetries: tt.maxRetries, RetryBackoff: time.Millisecond, BreakerThreshold: 1})
+
+			body, err := client.Predict(context.Background(), testRequest())
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("Predict() error = %v", err)
+				}
+				if string(body) != `{"forecast":[]}` {
+					t.Errorf("body = %q", body)
+				}
+			} else {
+				var mlErr *Error
+				if !errors.As(err, &mlErr) {
+					t.Fatalf("Predict() error = %v, want *Error", err)
+				}
+				if mlErr.Status != tt.wantStatus {
+					t.Errorf("status = %d, want %d", mlErr.Status, tt.wantStatus)
+				}
+			}
+			if got := atomic.LoadInt32(&hits); got != tt.wantHits {
+				t.Errorf("server hits = %d, want %d", got, tt.wantHits)
+			}
+			if got := client.breaker.State(); got != tt.wantBreaker {
+				t.Errorf("breaker = %q, want %q", got, tt.wantBreaker)
+			}
+		})
+	}
+}
+
+func TestHTTPClientPredictCircuitOpen(t *testing.T) {
+	var hits int32
+	srv := newTestServer(t, []int{500}, &hits)
+	client := New(Config{URL: srv.URL, MaxRetries: 0, RetryBackoff: time.Millisecond, BreakerThreshold: 1, BreakerCooldown: time.Minute})
+
+	if _, err := client.Predict(context.Background(), testRequest()); err == nil {
+		t.Fatal("first Predict() should fail")
+	}
+	_, err := client.Predict(context.Background(), testRequest())
+	var mlErr *Error
+	if !errors.As(err, &mlErr) || mlErr.Status != 0 || mlErr.Details != ErrCircuitOpen.Error() {
+		t.Fatalf("second Predict() error = %v, want circuit open", err)
+	}
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("server hits = %d, want 1 (circuit open must not call the ML service)", got)
+	}
+}
+
+func TestHTTPClientPredictUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	client := New(Config{URL: url, MaxRetries: 1, RetryBackoff: time.Millisecond, BreakerThreshold: 1})
+	_, err := client.Predict(context.Background(), testRequest())
+	var mlErr *Error
+	if !errors.As(err, &mlErr) || mlErr.Status != 0 {
+		t.Fatalf("Predict() error = %v, want unreachable (status 0)", err)
+	}
+	if got := client.breaker.State(); got != StateOpen {
+		t.Errorf("breaker = %q, want %q", got, StateOpen)
+	}
+}
+
+func TestHTTPClientPredictCanceled(t *testing.T) {
+	var hits int32
+	srv := newTestServer(t, []int{500}, &hits)
+	client := New(Config{URL: srv.URL, MaxRetries: 2, RetryBackoff: time.Hour, BreakerThreshold: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.Predict(ctx, testRequest()); err == nil {
+		t.Fatal("Predict() should fail")
+	}
+	// Dibatalkan saat backoff: bukan salah ML service, breaker gak boleh open
+	if got := client.breaker.State(); got != StateClosed {
+		t.Errorf("breaker = %q, want %q", got, StateClosed)
+	}
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Scope API key
+const (
+	ScopeRead              = "read"               // Semua endpoint GET (kecuali kelola user & API key)
+	ScopeTransactionsWrite = "transactions:write" // Input transaksi & order
+	ScopeForecast          = "forecast"           // Jalankan forecast (upload, transactions, jobs, backtest, batch)
+)
+
+// APIKey key buat integrasi antar sistem (ERP, ML service) tanpa login user.
+// Key asli cuma ditampilkan sekali waktu dibuat, di DB disimpan SHA-256-nya.
+type APIKey struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Name        string     `gorm:"size:100;not null" json:"name"`
+	Prefix      string     `gorm:"size:20;not null" json:"prefix"` // Awal key (mis. pnj_AbC123) buat identifikasi di log/UI
+	KeyHash     string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	Scopes      []string   `gorm:"type:jsonb;serializer:json;not null" json:"scopes"` // read / transactions:write / forecast
+	StoreID     *uint      `json:"store_id"`                                          // Null = semua toko
+	CreatedByID *uint      `json:"created_by_id,omitempty"`                           // User admin yang membuat
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`                              // Null = gak pernah expired
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP  string     `gorm:"size:45" json:"last_used_ip,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// HasScope cek apakah key punya scope tertentu
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
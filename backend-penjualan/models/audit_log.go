@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Aksi audit log
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"  // Soft delete (masuk trash)
+	AuditActionRestore = "restore" // Dikembalikan dari trash
+	AuditActionPurge   = "purge"   // Dihapus permanen dari trash
+)
+
+// Jenis actor audit log
+const (
+	AuditActorUser   = "user"
+	AuditActorAPIKey = "api_key"
+)
+
+// AuditChange nilai satu field sebelum & sesudah perubahan (null = belum ada / sudah dihapus)
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditLog jejak siapa mengubah apa (create/update/delete produk // AuditLog jejak siapa mengubah apa (create/update/delete produk & transaksi) transaksi, create/update order)
+type AuditLog struct {
+	ID        uint                   `gorm:"primaryKey" json:"id"`
+	ActorType string                 `gorm:"size:20;not null" json:"actor_type"` // user / api_key
+	ActorID   *uint                  `json:"actor_id"`
+	ActorName string                 `gorm:"size:100;not null" json:"actor_name"` // Username / nama API key saat itu
+	Action    string                 `gorm:"size:20;not null" json:"action"`      // create / update / delete / restore / purge
+	Entity    string                 `gorm:"size:50;not null" json:"entity"`      // product / transaction / order
+	EntityID  uint                   `gorm:"not null" json:"entity_id"`
+	StoreID   *uint                  `gorm:"index" json:"store_id"`
+	Changes   map[string]AuditChange `gorm:"type:jsonb;serializer:json;not null" json:"changes"` // Cuma field yang berubah
+	RequestID string                 `gorm:"size:100;index" json:"request_id"`
+	IP        string                 `gorm:"size:45" json:"ip,omitempty"`
+	CreatedAt time.Time              `gorm:"index" json:"created_at"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Customer data pembeli tetap (riwayat belanja di-link lewat customer_id)
+type Customer struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	Nama      string         `gorm:"size:100;not null" json:"nama"`
+	Telepon   string         `gorm:"size:30" json:"telepon"`
+	Email     string         `gorm:"size:100" json:"email"`
+	Alamat    string         `gorm:"type:text" json:"alamat"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index" swaggertype:"string" format:"date-time"` // Soft delete: riwayat belanja tetap ter-link
+}
@@ -0,0 +1,95 @@
+package models
+
+import "time"
+
+// Status forecast run
+const (
+	ForecastStatusSuccess = "success"
+	ForecastStatusFailed  = "failed"
+)
+
+// Jenis titik forecast
+const (
+	ForecastPointHistorical = "historical"
+	ForecastPointForecast   = "forecast"
+)
+
+// ForecastRun satu kali eksekusi forecast (input, parameter, model yang dipakai & status)
+type ForecastRun struct {
+	ID              uint            `gorm:"primaryKey" json:"id"`
+	Source          string          `gorm:"size:20;not null" json:"source"`             // upload / json / transactions
+	InputHash       string          `gorm:"size:64;not null;index" json:"input_hash"`   // SHA-256 file/series input
+	InputFilename   string          `gorm:"size:255" json:"input_filename,omitempty"`   // Nama file kalau upload
+	ProductID       *uint           `gorm:"index" json:"product_id,omitempty"`          // Kalau forecast per produk
+	StoreID         *uint           `gorm:"index" json:"store_id"`                      // Toko pembuat run; null = kantor pusat
+	Periods         int             `gorm:"not null" json:"periods"`                    // Jumlah hari yang diprediksi
+	ModelRequested  string          `gorm:"size:30" json:"model_requested"`             // Model yang diminta (auto, prophet, ...)
+	Model           string          `gorm:"size:30" json:"model"`                       // Model yang benar-benar dipakai
+	FallbackReason  string          `gorm:"type:text" json:"fallback_reason,omitempty"` // Kenapa pindah ke engine native
+	Status          string          `gorm:"size:20;not null;index" json:"status"`       // success / failed
+	Error           string          `gorm:"type:text" json:"error,omitempty"`           // Pesan error kalau failed
+	MAPE            *float64        `json:"mape,omitempty"`                             // Akurasi vs penjualan aktual (diisi evaluator terjadwal)
+	MAE             *float64        `json:"mae,omitempty"`
+	RMSE            *float64        `json:"rmse,omitempty"`
+	EvaluatedPoints int             `gorm:"not null;default:0" json:"evaluated_points"` // Jumlah hari forecast yang sudah ada actual-nya
+	EvaluatedAt     *time.Time      `json:"evaluated_at,omitempty"`
+	Points          []ForecastPoint `gorm:"foreignKey:RunID" json:"points,omitempty"` // Hasil (historical + forecast)
+	CreatedAt       time.Time       `gorm:"index" json:"created_at"`
+}
+
+// ForecastPoint satu titik hasil forecast run
+type ForecastPoint struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	RunID     uint      `gorm:"not null;index" json:"run_id"`
+	Kind      string    `gorm:"size:20;not null" json:"kind"` // historical / forecast
+	Date      time.Time `gorm:"type:date;not null" json:"date"`
+	Actual    *float64  `json:"actual,omitempty"`
+	Yhat      *float64  `json:"yhat,omitempty"`
+	YhatLower *float64  `json:"yhat_lower,omitempty"`
+	YhatUpper *float64  `json:"yhat_upper,omitempty"`
+	Trend     *float64  `json:"trend,omitempty"`    // Komponen trend (kalau model menyediakan)
+	Weekly    *float64  `json:"weekly,omitempty"`   // Komponen musiman mingguan
+	Yearly    *float64  `json:"yearly,omitempty"`   // Komponen musiman tahunan (Prophet)
+	Holidays  *float64  `json:"holidays,omitempty"` // Efek holiday/event
+}
+
+// Status forecast job (async)
+const (
+	ForecastJobQueued  = "queued"
+	ForecastJobRunning = "running"
+	ForecastJobDone    = "done"
+	ForecastJobFailed  = "failed"
+)
+
+// ForecastJob forecast yang dijalankan di background worker. State disimpan di Postgres
+// biar job yang belum selesai tetap jalan lagi setelah server restart.
+type ForecastJob struct {
+	ID            uint         `gorm:"primaryKey" json:"id"`
+	Status        string       `gorm:"size:20;not null;index" json:"status"`     // queued / running / done / failed
+	Source        string       `gorm:"size:20;not null" json:"source"`           // upload / json / transactions
+	InputHash     string       `gorm:"size:64;not null" json:"input_hash"`       // SHA-256 input
+	InputFilename string       `gorm:"size:255" json:"input_filename,omitempty"` // Nama file kalau upload
+	ProductID     *uint        `json:"product_id,omitempty"`
+	StoreID       *uint        `gorm:"index" json:"store_id"` // Toko pembuat job; null = kantor pusat
+	Model         string       `gorm:"size:30" json:"model"`
+	Periods       int          `gorm:"not null" json:"periods"`
+	Input         string       `gorm:"type:text;not null" json:"-"`           // Series ternormalisasi (CSV date,projected_quantity)
+	RunID         *uint        `json:"run_id,omitempty"`                      // Forecast run hasil job (kalau sudah jalan)
+	Run           *ForecastRun `gorm:"foreignKey:RunID" json:"run,omitempty"` // Diisi di GET kalau status done/failed
+	Error         string       `gorm:"type:text" json:"error,omitempty"`
+	Attempts      int          `gorm:"not null;default:0" json:"attempts"`
+	StartedAt     *time.Time   `json:"started_at,omitempty"`
+	FinishedAt    *time.Time   `json:"finished_at,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+// ForecastCacheEntry hasil forecast yang di-cache (response JSON), key = hash input + parameter
+type ForecastCacheEntry struct {
+	Key       string    `gorm:"primaryKey;size:64" json:"key"`
+	Value     []byte    `gorm:"not null" json:"-"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (ForecastCacheEntry) TableName() string { return "forecast_cache" }
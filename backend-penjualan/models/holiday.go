@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Kategori holiday
+const (
+	HolidayKategoriNasional = "nasional" // Libur nasional
+	HolidayKategoriEvent    = "event"    // Event belanja (Harbolnas, 11.11, dll)
+)
+
+// Holiday hari libur / event yang mempengaruhi penjualan, dipakai sebagai regressor forecast.
+// Tanggal yang berpindah tiap tahun (Lebaran, Imlek, ...) disimpan satu row per tahun dengan nama yang sama.
+type Holiday struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Nama        string    `gorm:"size:100;not null" json:"nama"`
+	Tanggal     time.Time `gorm:"type:date;not null;index" json:"tanggal"`
+	Kategori    string    `gorm:"size:20;not null;default:nasional" json:"kategori"` // nasional / event
+	LowerWindow int       `gorm:"not null;default:0" json:"lower_window"`            // Efek mulai N hari sebelumnya (<= 0, mis. -7 jelang Lebaran)
+	UpperWindow int       `gorm:"not null;default:0" json:"upper_window"`            // Efek sampai N hari sesudahnya (>= 0)
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Order header penjualan multi-item (satu pembeli, banyak produk)
+type Order struct {
+	ID            uint        `gorm:"primaryKey" json:"id"`
+	StoreID       uint        `gorm:"not null;index" json:"store_id"` // Semua item harus produk toko ini
+	NamaPembeli   string      `gorm:"size:100;not null" json:"nama_pembeli"`
+	CustomerID    *uint       `gorm:"index" json:"customer_id"`
+	Customer      *Customer   `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	TotalQuantity uint        `gorm:"not null" json:"total_quantity"`
+	Total         float64     `gorm:"type:numeric(15,2);not null" json:"total"`
+	Items         []OrderItem `gorm:"foreignKey:OrderID" json:"items"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// OrderItem satu baris produk di order, harga di-snapshot saat order dibuat
+type OrderItem struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	OrderID   uint      `gorm:"not null;index" json:"order_id"`
+	ProductID uint      `gorm:"not null" json:"product_id"`
+	Product   Product   `gorm:"foreignKey:ProductID" json:"product"`
+	Quantity  uint      `gorm:"not null" json:"quantity"`
+	Harga     float64   `gorm:"type:numeric(15,2);not null" json:"harga"`    // Harga satuan saat order
+	Subtotal  float64   `gorm:"type:numeric(15,2);not null" json:"subtotal"` // Quantity * Harga
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -1,12 +1,27 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultLeadTimeDays lead time kalau lead_time_days gak dikirim waktu create produk
+	DefaultLeadTimeDays = 7
+	// MaxLeadTimeDays batas lead time (setahun), sama dengan horizon forecast maksimal
+	MaxLeadTimeDays = 365
+)
 
 type Product struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	Nama      string    `gorm:"size:100;not null" json:"nama"`
-	Harga     float64   `gorm:"type:numeric(15,2);not null" json:"harga"` // FIXED: (15,2) biar max triliunan
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty" gorm:"index"`
-}
\ No newline at end of file
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	StoreID      uint           `gorm:"not null;index" json:"store_id"` // Toko pemilik produk & stoknya
+	Nama         string         `gorm:"size:100;not null" json:"nama"`
+	Harga        float64        `gorm:"type:numeric(15,2);not null" json:"harga"` // FIXED: (15,2) biar max triliunan
+	Stok         int            `gorm:"not null;default:0" json:"stok"`           // Stok di rak, gak boleh minus
+	LeadTimeDays int            `gorm:"not null" json:"lead_time_days"`           // Lama restock dari supplier (hari), 0 = supplier same-day
+	SafetyStock  int            `gorm:"not null;default:0" json:"safety_stock"`   // Stok cadangan minimal
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `json:"deleted_at" gorm:"index" swaggertype:"string" format:"date-time"` // Soft delete: null = aktif, terisi = di trash
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// DefaultStoreID toko bawaan; semua data sebelum multi-store masuk ke sini
+const DefaultStoreID = 1
+
+// Store cabang toko. Produk (beserta stok), transaksi & order milik satu toko.
+type Store struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Kode      string    `gorm:"size:20;not null;uniqueIndex" json:"kode"` // Kode singkat, mis. JKT01
+	Nama      string    `gorm:"size:100;not null" json:"nama"`
+	Alamat    string    `gorm:"type:text" json:"alamat"`
+	Active    bool      `gorm:"not null" json:"active"` // Toko nonaktif gak bisa input data baru (default true diisi handler, bukan tag GORM)
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
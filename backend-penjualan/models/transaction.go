@@ -1,16 +1,23 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Transaction struct {
-	ID           uint      `gorm:"primaryKey" json:"id"`
-	NamaPembeli  string    `gorm:"size:100;not null" json:"nama_pembeli"`
-	ProductID    uint      `gorm:"not null" json:"product_id"`
-	Product      Product   `gorm:"foreignKey:ProductID" json:"product"`
-	Quantity     uint      `gorm:"not null" json:"quantity"`
-	Harga        float64   `gorm:"type:numeric(15,2);not null" json:"harga"`
-	Total        float64   `gorm:"type:numeric(15,2);not null" json:"total"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
-	DeletedAt    *time.Time `json:"deleted_at,omitempty" gorm:"index"`
-}
\ No newline at end of file
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	StoreID     uint           `gorm:"not null;index" json:"store_id"` // Sama dengan toko produknya
+	NamaPembeli string         `gorm:"size:100;not null" json:"nama_pembeli"`
+	CustomerID  *uint          `gorm:"index" json:"customer_id"` // Optional, kosong untuk pembeli walk-in
+	Customer    *Customer      `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	ProductID   uint           `gorm:"not null" json:"product_id"`
+	Product     Product        `gorm:"foreignKey:ProductID" json:"product"`
+	Quantity    uint           `gorm:"not null" json:"quantity"`
+	Harga       float64        `gorm:"type:numeric(15,2);not null" json:"harga"`
+	Total       float64        `gorm:"type:numeric(15,2);not null" json:"total"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index" swaggertype:"string" format:"date-time"` // Soft delete: null = aktif, terisi = di trash
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Role user
+const (
+	RoleAdmin   = "admin"   // Semua akses, termasuk kelola user
+	RoleManager = "manager" // Kelola produk, holiday & hapus data
+	RoleCashier = "cashier" // Input transaksi, order & customer
+)
+
+// User akun login (password disimpan sebagai hash bcrypt)
+type User struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	Username     string     `gorm:"size:50;not null;uniqueIndex" json:"username"`
+	Nama         string     `gorm:"size:100" json:"nama"`
+	PasswordHash string     `gorm:"size:100;not null" json:"-"`
+	Role         string     `gorm:"size:20;not null;default:cashier" json:"role"` // admin / manager / cashier
+	StoreID      *uint      `gorm:"index" json:"store_id"`                        // Toko tempat user bertugas; null = kantor pusat (semua toko)
+	Active       bool       `gorm:"not null" json:"active"`                       // Default true di handler & migration (tag default GORM bikin false ikut di-skip waktu insert)
+	LastLoginAt  *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// RefreshToken sesi login; token asli cuma dikirim ke client, di DB disimpan SHA-256-nya.
+// Dirotasi tiap /auth/refresh (token lama di-revoke).
+type RefreshToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	TokenHash string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	UserAgent string     `gorm:"size:255" json:"user_agent,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -9,21 +9,27 @@ import (
 	swaggerFiles "github.com/swaggo/files"
 	"github.com/gin-contrib/cors"
 
+	"backend-penjualan/audit"
+	"backend-penjualan/auth"
 	"backend-penjualan/controllers"
+	"backend-penjualan/models"
 	"gorm.io/gorm"
 )
 
-// SetupRouter inisialisasi router dengan semua routes (products, transactions, forecast)
-func SetupRouter(db *gorm.DB) *gin.Engine {
+// SetupRouter inisialisasi router dengan semua routes (auth, users, stores, audit, products, transactions, orders, customers, reports, holidays, inventory, forecast).
+// Semua route /api/v1 wajib login (JWT) atau API key, kecuali /api/v1/auth/login, /refresh & /logout.
+// forecastCtrl dibuat di main karena worker background-nya ikut lifecycle server (StartBackground).
+func SetupRouter(db *gorm.DB, authCfg auth.Config, forecastCtrl *controllers.UploadForecastController) *gin.Engine {
 	r := gin.Default()
+	r.Use(audit.RequestID()) // X-Request-ID di tiap response, ikut dicatat di audit log
 
 	// CORS untuk frontend (localhost:3000)
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000", "http://127.0.0.1:3000"},
 		AllowMethods:     []string{"GET", "POST", "PATCH", "DELETE", "OPTIONS", "PUT"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", auth.StoreHeader, audit.RequestIDHeader},
 		AllowCredentials: true,
-		ExposeHeaders:    []string{"Content-Length"},
+		ExposeHeaders:    []string{"Content-Length", "Location", "X-Cache", "X-Total-Count", "X-Page", "X-Limit", audit.RequestIDHeader},
 	}))
 
 	// Health check sederhana (update timestamp ke current)
@@ -34,41 +40,124 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 	// Swagger docs (jalankan di /swagger/index.html)
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// API v1 group
-	v1 := r.Group("/api/v1")
+	// Auth routes (tanpa token)
+	authCtrl := controllers.NewAuthController(db, authCfg)
+	public := r.Group("/api/v1/auth")
 	{
+		public.POST("/login", authCtrl.Login)
+		public.POST("/refresh", authCtrl.Refresh)
+		public.POST("/logout", authCtrl.Logout)
+	}
+
+	// API v1 group (wajib Authorization: Bearer <access token> atau API key)
+	// StoreContext: toko aktif dari user/API key cabang, atau X-Store-ID buat kantor pusat
+	v1 := r.Group("/api/v1", auth.Middleware(authCfg, db), auth.StoreContext(db))
+	{
+		// Hak akses per route (Authorize): role buat user login, scope buat API key (scope kosong = API key ditolak).
+		// Cashier cuma input transaksi, order & customer; ubah master data & hapus data khusus admin/manager.
+		read := auth.Authorize(models.ScopeRead)
+		writeTransactions := auth.Authorize(models.ScopeTransactionsWrite)
+		runForecast := auth.Authorize(models.ScopeForecast)
+		usersOnly := auth.Authorize("")
+		managers := auth.Authorize("", models.RoleAdmin, models.RoleManager)
+		managersOrForecast := auth.Authorize(models.ScopeForecast, models.RoleAdmin, models.RoleManager)
+		adminOnly := auth.Authorize("", models.RoleAdmin)
+
 		// Inisialisasi controllers di sini (butuh db)
+		userCtrl := controllers.NewUserController(db)
+		apiKeyCtrl := controllers.NewAPIKeyController(db)
+		storeCtrl := controllers.NewStoreController(db)
+		auditCtrl := controllers.NewAuditController(db)
 		productCtrl := controllers.NewProductController(db)
 		transactionCtrl := controllers.NewTransactionController(db)
-		forecastCtrl := controllers.NewForecastController()  // Tambah ini!
+		orderCtrl := controllers.NewOrderController(db)
+		customerCtrl := controllers.NewCustomerController(db)
+		reportCtrl := controllers.NewReportController(db)
+		holidayCtrl := controllers.NewHolidayController(db)
+		inventoryCtrl := controllers.NewInventoryController(db, forecastCtrl.ML)
+
+		// Auth routes (user yang sedang login)
+		v1.GET("/auth/me", usersOnly, authCtrl.Me)
+		v1.PUT("/auth/password", usersOnly, authCtrl.ChangePassword)
+
+		// Users routes (admin saja)
+		v1.GET("/users", adminOnly, userCtrl.GetAll)
+		v1.POST("/users", adminOnly, userCtrl.Create)
+		v1.GET("/users/:id", adminOnly, userCtrl.GetByID)
+		v1.PUT("/users/:id", adminOnly, userCtrl.Update)
+
+		// API keys routes (admin saja, buat integrasi ERP / ML service)
+		v1.GET("/api-keys", adminOnly, apiKeyCtrl.GetAll)
+		v1.POST("/api-keys", adminOnly, apiKeyCtrl.Create)
+		v1.DELETE("/api-keys/:id", adminOnly, apiKeyCtrl.Revoke)
+
+		// Stores routes (cabang; ubah data toko khusus admin)
+		v1.GET("/stores", read, storeCtrl.GetAll)
+		v1.POST("/stores", adminOnly, storeCtrl.Create)
+		v1.GET("/stores/:id", read, storeCtrl.GetByID)
+		v1.PUT("/stores/:id", adminOnly, storeCtrl.Update)
+
+		// Audit log (riwayat perubahan produk & transaksi)
+		v1.GET("/audit", managers, auditCtrl.GetAll)
 
 		// Products routes
-		v1.GET("/products", productCtrl.GetAll)
-		v1.POST("/products", productCtrl.Create)
-		v1.GET("/products/:id", productCtrl.GetByID)
-		v1.PUT("/products/:id", productCtrl.Update)
-		v1.DELETE("/products/:id", productCtrl.Delete)
+		v1.GET("/products", read, productCtrl.GetAll)
+		v1.POST("/products", managers, productCtrl.Create)
+		v1.GET("/products/:id", read, productCtrl.GetByID)
+		v1.PUT("/products/:id", managers, productCtrl.Update)
+		v1.DELETE("/products/:id", managers, productCtrl.Delete) // Soft delete, masuk trash
+		v1.GET("/products/trash", managers, productCtrl.Trash)
+		v1.POST("/products/:id/restore", managers, productCtrl.Restore)
+		v1.DELETE("/products/trash", adminOnly, productCtrl.Purge) // Hapus permanen isi trash
 
 		// Transactions routes
-		v1.GET("/transactions", transactionCtrl.GetAll)
-		v1.POST("/transactions", transactionCtrl.Create)
-		v1.GET("/transactions/:id", transactionCtrl.GetByID)
-		v1.PATCH("/transactions/:id", transactionCtrl.Update)
-		v1.DELETE("/transactions/:id", transactionCtrl.Delete)
+		v1.GET("/transactions", read, transactionCtrl.GetAll)
+		v1.POST("/transactions", writeTransactions, transactionCtrl.Create)
+		v1.GET("/transactions/:id", read, transactionCtrl.GetByID)
+		v1.PATCH("/transactions/:id", managers, transactionCtrl.Update)
+		v1.DELETE("/transactions/:id", managers, transactionCtrl.Delete) // Soft delete, stok dikembalikan
+		v1.GET("/transactions/trash", managers, transactionCtrl.Trash)
+		v1.POST("/transactions/:id/restore", managers, transactionCtrl.Restore) // Stok dipesan ulang
+		v1.DELETE("/transactions/trash", adminOnly, transactionCtrl.Purge)
+
+		// Customers routes
+		v1.GET("/customers", read, customerCtrl.GetAll)
+		v1.POST("/customers", usersOnly, customerCtrl.Create)
+		v1.GET("/customers/:id", read, customerCtrl.GetByID)
+		v1.PUT("/customers/:id", usersOnly, customerCtrl.Update)
+		v1.DELETE("/customers/:id", managers, customerCtrl.Delete)
+		v1.GET("/customers/:id/transactions", read, customerCtrl.Transactions)
+
+		// Orders routes (multi-item, berdampingan dengan /transactions)
+		v1.GET("/orders", read, orderCtrl.GetAll)
+		v1.POST("/orders", writeTransactions, orderCtrl.Create)
+		v1.GET("/orders/:id", read, orderCtrl.GetByID)
+		v1.PATCH("/orders/:id", managers, orderCtrl.Update) // Sama dengan PATCH /transactions: stok dilepas & dipesan ulang
+
+		// Reports routes (agregat SQL, timezone Asia/Jakarta)
+		v1.GET("/reports/sales", read, reportCtrl.Sales)
+
+		// Holidays routes (kalender libur & event, regressor forecast)
+		v1.GET("/holidays", read, holidayCtrl.GetAll)
+		v1.POST("/holidays", managers, holidayCtrl.Create)
+		v1.GET("/holidays/:id", read, holidayCtrl.GetByID)
+		v1.PUT("/holidays/:id", managers, holidayCtrl.Update)
+		v1.DELETE("/holidays/:id", managers, holidayCtrl.Delete)
+
+		// Inventory routes (rekomendasi restock dari forecast)
+		v1.GET("/inventory/recommendations", read, inventoryCtrl.Recommendations)
 
 		// Forecast routes (baru!)
-		v1.POST("/forecast/upload", forecastCtrl.UploadHandler)
-		// Tambahan: Health check buat ML service (test koneksi)
-		v1.GET("/forecast/health", func(c *gin.Context) {
-			// Simple ping ke ML URL (dari controller config)
-			url := forecastCtrl.FastAPIURL
-			resp, err := http.Get(url + "/health") // Asumsi ML punya /health, atau ganti ke root
-			if err != nil || resp.StatusCode != http.StatusOK {
-				c.JSON(http.StatusServiceUnavailable, gin.H{"status": "ML service down", "details": err.Error()})
-				return
-			}
-			c.JSON(http.StatusOK, gin.H{"status": "ML service healthy", "url": url})
-		})
+		v1.POST("/forecast/upload", runForecast, forecastCtrl.UploadHandler)
+		v1.POST("/forecast/transactions", runForecast, forecastCtrl.FromTransactions) // Langsung dari tabel transactions, tanpa CSV
+		v1.GET("/forecast/runs", read, forecastCtrl.ListRuns)
+		v1.POST("/forecast/runs/evaluate", managersOrForecast, forecastCtrl.EvaluateRuns) // Bandingkan forecast lama dengan penjualan aktual
+		v1.GET("/forecast/runs/:id", read, forecastCtrl.GetRun)
+		v1.POST("/forecast/backtest", runForecast, forecastCtrl.Backtest)
+		v1.POST("/forecast/batch", managersOrForecast, forecastCtrl.Batch) // Semua produk aktif / product_ids, paralel dengan batas concurrency
+		v1.POST("/forecast/jobs", runForecast, forecastCtrl.CreateJob) // Async: return 202 + job ID, poll GET /forecast/jobs/:id
+		v1.GET("/forecast/jobs/:id", read, forecastCtrl.GetJob)
+		v1.GET("/forecast/health", read, forecastCtrl.Health) // Cek koneksi ke ML service + state circuit breaker
 	}
 
 	return r